@@ -2,14 +2,17 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"time"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 
+	"refleks/internal/archive"
 	"refleks/internal/benchmarks"
 	"refleks/internal/constants"
+	"refleks/internal/filters"
 	"refleks/internal/models"
 	"refleks/internal/mouse"
 	appsettings "refleks/internal/settings"
@@ -91,11 +94,7 @@ func (a *App) startup(ctx context.Context) {
 func (a *App) StartWatcher(path string) (bool, string) {
 	if path == "" {
 		// default to settings
-		if a.settings.StatsDir != "" {
-			path = a.settings.StatsDir
-		} else {
-			path = appsettings.DefaultStatsDir()
-		}
+		path = a.statsDir()
 	} else {
 		// update settings if provided by UI
 		a.settings.StatsDir = path
@@ -108,6 +107,13 @@ func (a *App) StartWatcher(path string) (bool, string) {
 		if a.mouse != nil {
 			a.watcher.SetMouseProvider(a.mouse)
 		}
+		if set, err := filters.Compile(a.settings.ScenarioFilters, a.settings.HiddenScenarios); err == nil {
+			a.watcher.SetFilters(set)
+		} else {
+			runtime.LogWarningf(a.ctx, "scenario filters ignored: %v", err)
+		}
+		a.watcher.SetAnomalyExclusion(a.settings.ExcludeAnomalousRuns)
+		a.watcher.SetCurrentVersionOnly(a.settings.CurrentVersionOnly)
 	} else {
 		if err := a.watcher.UpdateConfig(cfg); err != nil {
 			return false, err.Error()
@@ -122,6 +128,14 @@ func (a *App) StartWatcher(path string) (bool, string) {
 	return true, "ok"
 }
 
+// statsDir returns the configured stats directory, falling back to the platform default.
+func (a *App) statsDir() string {
+	if a.settings.StatsDir != "" {
+		return a.settings.StatsDir
+	}
+	return appsettings.DefaultStatsDir()
+}
+
 // StopWatcher stops the watcher if running.
 func (a *App) StopWatcher() (bool, string) {
 	if a.watcher == nil {
@@ -141,18 +155,349 @@ func (a *App) GetRecentScenarios(limit int) []models.ScenarioRecord {
 	return a.watcher.GetRecent(limit)
 }
 
+// GetSessions returns up to limit sessions (newest first) as grouped by the backend.
+func (a *App) GetSessions(limit int) []models.Session {
+	if a.watcher == nil {
+		return nil
+	}
+	return a.watcher.GetSessions(limit)
+}
+
+// GetPersonalBests returns the current personal best of every scenario in the full history.
+func (a *App) GetPersonalBests() []models.PersonalBest {
+	if a.watcher == nil {
+		return nil
+	}
+	return a.watcher.GetPersonalBests()
+}
+
+// GetPersonalBestHistory returns the PB progression of a scenario, oldest first.
+func (a *App) GetPersonalBestHistory(scenario string) []models.PersonalBest {
+	if a.watcher == nil {
+		return nil
+	}
+	return a.watcher.GetPersonalBestHistory(scenario)
+}
+
+// GetScenarioSummary returns aggregate statistics for a scenario over its full history.
+func (a *App) GetScenarioSummary(name string) (models.ScenarioSummary, error) {
+	if a.watcher == nil {
+		return models.ScenarioSummary{}, errors.New("watcher not started")
+	}
+	sum, ok := a.watcher.GetScenarioSummary(name)
+	if !ok {
+		return models.ScenarioSummary{}, fmt.Errorf("no runs recorded for scenario %q", name)
+	}
+	return sum, nil
+}
+
+// GetScenarioLineage returns the scenario hashes and game versions a scenario was
+// played on, with the runs, best score and date range of each, and their boundaries.
+func (a *App) GetScenarioLineage(name string) (models.ScenarioLineage, error) {
+	if a.watcher == nil {
+		return models.ScenarioLineage{}, errors.New("watcher not started")
+	}
+	return a.watcher.ScenarioLineage(name), nil
+}
+
+// GetHighscorePrediction forecasts the next personal best for a scenario: the predicted
+// score, the expected number of runs to reach it and a confidence interval on that count.
+func (a *App) GetHighscorePrediction(name string) (models.HighscorePrediction, error) {
+	if a.watcher == nil {
+		return models.HighscorePrediction{}, errors.New("watcher not started")
+	}
+	return a.watcher.PredictHighscore(name), nil
+}
+
+// GetSessionLengthRecommendations returns the recommended attempts per session for each
+// scenario. Scenarios with too few sessions are included with Sufficient unset.
+func (a *App) GetSessionLengthRecommendations() ([]models.SessionLengthRecommendation, error) {
+	if a.watcher == nil {
+		return nil, errors.New("watcher not started")
+	}
+	return a.watcher.SessionLengthRecommendations(), nil
+}
+
+// GetFatigueProfiles returns, per scenario, where performance plateaus after warming up
+// and where it starts to decline within a session.
+func (a *App) GetFatigueProfiles() ([]models.FatigueProfile, error) {
+	if a.watcher == nil {
+		return nil, errors.New("watcher not started")
+	}
+	return a.watcher.FatigueProfiles(), nil
+}
+
+// GetSensitivityAnalysis returns, per scenario and per scenario type, how performance
+// varies with cm/360 after removing the improvement trend, with a recommended range.
+func (a *App) GetSensitivityAnalysis() ([]models.SensitivityAnalysis, error) {
+	if a.watcher == nil {
+		return nil, errors.New("watcher not started")
+	}
+	return a.watcher.SensitivityAnalysis(), nil
+}
+
+// GetSensitivityAnalysisFor returns the sensitivity analysis of a single scenario.
+func (a *App) GetSensitivityAnalysisFor(name string) (models.SensitivityAnalysis, error) {
+	if a.watcher == nil {
+		return models.SensitivityAnalysis{}, errors.New("watcher not started")
+	}
+	return a.watcher.ScenarioSensitivity(name), nil
+}
+
+// GetTimeOfDayAnalysis returns normalized performance by hour of day and weekday, with
+// the best and worst three-hour windows.
+func (a *App) GetTimeOfDayAnalysis() (models.TimeOfDayAnalysis, error) {
+	if a.watcher == nil {
+		return models.TimeOfDayAnalysis{}, errors.New("watcher not started")
+	}
+	return a.watcher.TimeOfDayAnalysis(), nil
+}
+
+// GetTrendFindings returns the current score plateaus and regressions of every scenario,
+// with any setup changes found around each regression.
+func (a *App) GetTrendFindings() ([]models.TrendFinding, error) {
+	if a.watcher == nil {
+		return nil, errors.New("watcher not started")
+	}
+	return a.watcher.TrendFindings(), nil
+}
+
+// GetSkillRatings returns a cross-scenario rating with daily history for every
+// scenario category (clicking, tracking, switching).
+func (a *App) GetSkillRatings() ([]models.CategoryRating, error) {
+	if a.watcher == nil {
+		return nil, errors.New("watcher not started")
+	}
+	return a.watcher.SkillRatings(), nil
+}
+
+// GetGoals returns every goal with its progress, ETA and achievement status.
+func (a *App) GetGoals() ([]models.GoalStatus, error) {
+	if a.watcher == nil {
+		return nil, errors.New("watcher not started")
+	}
+	return a.watcher.Goals()
+}
+
+// GetGoalStatus returns the status of one goal.
+func (a *App) GetGoalStatus(id string) (models.GoalStatus, error) {
+	if a.watcher == nil {
+		return models.GoalStatus{}, errors.New("watcher not started")
+	}
+	st, ok := a.watcher.GoalStatus(id)
+	if !ok {
+		return models.GoalStatus{}, fmt.Errorf("goal %q not found", id)
+	}
+	return st, nil
+}
+
+// AddGoal creates a goal and returns it with its assigned ID.
+func (a *App) AddGoal(g models.Goal) (models.Goal, error) {
+	if a.watcher == nil {
+		return models.Goal{}, errors.New("watcher not started")
+	}
+	return a.watcher.AddGoal(g)
+}
+
+// UpdateGoal edits an existing goal's scenario, metric, target or deadline.
+func (a *App) UpdateGoal(g models.Goal) (bool, string) {
+	if a.watcher == nil {
+		return false, "watcher not started"
+	}
+	if err := a.watcher.UpdateGoal(g); err != nil {
+		return false, err.Error()
+	}
+	return true, "ok"
+}
+
+// DeleteGoal removes a goal.
+func (a *App) DeleteGoal(id string) (bool, string) {
+	if a.watcher == nil {
+		return false, "watcher not started"
+	}
+	if err := a.watcher.DeleteGoal(id); err != nil {
+		return false, err.Error()
+	}
+	return true, "ok"
+}
+
+// GetRoutines returns every routine with today's progress, next scenario and streaks.
+func (a *App) GetRoutines() ([]models.RoutineStatus, error) {
+	if a.watcher == nil {
+		return nil, errors.New("watcher not started")
+	}
+	return a.watcher.Routines()
+}
+
+// GetRoutineStatus returns the status of one routine.
+func (a *App) GetRoutineStatus(id string) (models.RoutineStatus, error) {
+	if a.watcher == nil {
+		return models.RoutineStatus{}, errors.New("watcher not started")
+	}
+	st, ok := a.watcher.RoutineStatus(id)
+	if !ok {
+		return models.RoutineStatus{}, fmt.Errorf("routine %q not found", id)
+	}
+	return st, nil
+}
+
+// AddRoutine creates a routine and returns it with its assigned ID.
+func (a *App) AddRoutine(r models.Routine) (models.Routine, error) {
+	if a.watcher == nil {
+		return models.Routine{}, errors.New("watcher not started")
+	}
+	return a.watcher.AddRoutine(r)
+}
+
+// UpdateRoutine edits an existing routine.
+func (a *App) UpdateRoutine(r models.Routine) (bool, string) {
+	if a.watcher == nil {
+		return false, "watcher not started"
+	}
+	if err := a.watcher.UpdateRoutine(r); err != nil {
+		return false, err.Error()
+	}
+	return true, "ok"
+}
+
+// DeleteRoutine removes a routine.
+func (a *App) DeleteRoutine(id string) (bool, string) {
+	if a.watcher == nil {
+		return false, "watcher not started"
+	}
+	if err := a.watcher.DeleteRoutine(id); err != nil {
+		return false, err.Error()
+	}
+	return true, "ok"
+}
+
+// PlayNextRoutineScenario launches the routine's next unfinished scenario for today.
+func (a *App) PlayNextRoutineScenario(id string) (bool, string) {
+	st, err := a.GetRoutineStatus(id)
+	if err != nil {
+		return false, err.Error()
+	}
+	if !st.ScheduledToday {
+		return false, "routine not scheduled today"
+	}
+	if st.NextScenario == "" {
+		return false, "routine already completed today"
+	}
+	return a.LaunchKovaaksScenario(st.NextScenario, "challenge")
+}
+
+// GetPracticeVolume returns daily and weekly playtime, run counts and practice streaks.
+func (a *App) GetPracticeVolume() (models.PracticeVolume, error) {
+	if a.watcher == nil {
+		return models.PracticeVolume{}, errors.New("watcher not started")
+	}
+	return a.watcher.PracticeVolume(), nil
+}
+
+// GetActivityHeatmap returns one entry per day for the last days days (365 when days
+// is not positive), including days without practice.
+func (a *App) GetActivityHeatmap(days int) ([]models.DayActivity, error) {
+	if a.watcher == nil {
+		return nil, errors.New("watcher not started")
+	}
+	if days <= 0 {
+		days = 365
+	}
+	return a.watcher.ActivityHeatmap(days), nil
+}
+
+// CompareRuns compares run idB against run idA (stats file names): stats, kill-by-kill
+// pace, setup differences and, when both were captured, mouse trace metrics.
+func (a *App) CompareRuns(idA, idB string) (models.RunComparison, error) {
+	if a.watcher == nil {
+		return models.RunComparison{}, errors.New("watcher not started")
+	}
+	return a.watcher.CompareRuns(idA, idB)
+}
+
+// GetTraceKinematics returns the velocity, acceleration and jerk profile of a run's
+// persisted mouse trace (by stats file name) with path and motion metrics and its
+// velocity spectrum (tremor band power and smoothness). unit is
+// "counts" (default), "deg" or "cm"; the latter two use the run's sensitivity and DPI.
+func (a *App) GetTraceKinematics(id, unit string) (models.TraceKinematics, error) {
+	if a.watcher == nil {
+		return models.TraceKinematics{}, errors.New("watcher not started")
+	}
+	return a.watcher.TraceKinematics(id, unit)
+}
+
+// GetTrace returns a run's mouse trace (by stats file name) between from and to
+// seconds after its first sample (to <= 0 for the end), downsampled to at most
+// maxPoints samples (<= 0 for all) while keeping the path's shape.
+func (a *App) GetTrace(id string, maxPoints int, from, to float64) (models.TraceSlice, error) {
+	if a.watcher == nil {
+		return models.TraceSlice{}, errors.New("watcher not started")
+	}
+	return a.watcher.Trace(id, maxPoints, from, to)
+}
+
+// GetSmoothnessTrend returns the tremor-band power and spectral smoothness of every
+// run of a scenario with a recorded mouse trace, with per-run trend slopes.
+func (a *App) GetSmoothnessTrend(scenario string) (models.SmoothnessTrend, error) {
+	if a.watcher == nil {
+		return models.SmoothnessTrend{}, errors.New("watcher not started")
+	}
+	return a.watcher.SmoothnessTrend(scenario), nil
+}
+
+// GetConvertedTrace returns a run's mouse trace as degrees of yaw and pitch ("deg") or
+// centimetres of mouse travel ("cm"), so traces at different sensitivities overlay.
+func (a *App) GetConvertedTrace(id, unit string) ([]models.TracePoint, error) {
+	if a.watcher == nil {
+		return nil, errors.New("watcher not started")
+	}
+	return a.watcher.ConvertedTrace(id, unit)
+}
+
+// GetFlickAnalysis breaks a run's mouse trace (by stats file name) into one flick per
+// kill with reaction, peak speed, overshoot, corrections and settle time, and
+// averages them by direction and distance. unit is "counts" (default), "deg" or "cm".
+func (a *App) GetFlickAnalysis(id, unit string) (models.FlickAnalysis, error) {
+	if a.watcher == nil {
+		return models.FlickAnalysis{}, errors.New("watcher not started")
+	}
+	return a.watcher.FlickAnalysis(id, unit)
+}
+
+// GetSetupImpact detects setup changes (sensitivity, FOV, crosshair, resolution) and
+// reports, per scenario, how runs after each change compare with the learning trend.
+func (a *App) GetSetupImpact() (models.SetupAnalysis, error) {
+	if a.watcher == nil {
+		return models.SetupAnalysis{}, errors.New("watcher not started")
+	}
+	return a.watcher.SetupImpact(), nil
+}
+
+// GetRunAnomalies returns runs whose technical stats (FPS, time dilation, input lag,
+// resolution and target scale) deviate from the player's norm, newest first.
+func (a *App) GetRunAnomalies() ([]models.RunAnomaly, error) {
+	if a.watcher == nil {
+		return nil, errors.New("watcher not started")
+	}
+	return a.watcher.RunAnomalies(), nil
+}
+
 // GetBenchmarks returns the embedded benchmarks list for the Explore UI.
 func (a *App) GetBenchmarks() ([]models.Benchmark, error) {
 	return benchmarks.GetBenchmarks()
 }
 
 // GetBenchmarkProgress fetches live player progress for a given difficulty benchmarkId.
-// Returns raw JSON string to preserve original key order from upstream.
+// Returns raw JSON string to preserve original key order from upstream. The scenario
+// categories and rank thresholds in the response are remembered for skill ratings.
 func (a *App) GetBenchmarkProgress(benchmarkId int) (string, error) {
 	data, err := benchmarks.GetPlayerProgressRaw(benchmarkId)
 	if err != nil {
 		return "", err
 	}
+	if err := benchmarks.UpdateIndex(benchmarkId, data); err != nil {
+		runtime.LogWarningf(a.ctx, "benchmark index update failed: %v", err)
+	}
 	return data, nil
 }
 
@@ -176,6 +521,17 @@ func (a *App) UpdateSettings(s models.Settings) (bool, string) {
 	if len(s.FavoriteBenchmarks) == 0 && len(a.settings.FavoriteBenchmarks) > 0 {
 		s.FavoriteBenchmarks = a.settings.FavoriteBenchmarks
 	}
+	// Filters are edited separately; nil means omitted, an empty list clears them
+	if s.ScenarioFilters == nil {
+		s.ScenarioFilters = a.settings.ScenarioFilters
+	}
+	if s.HiddenScenarios == nil {
+		s.HiddenScenarios = a.settings.HiddenScenarios
+	}
+	filterSet, err := filters.Compile(s.ScenarioFilters, s.HiddenScenarios)
+	if err != nil {
+		return false, err.Error()
+	}
 	a.settings = s
 	if err := appsettings.Save(a.settings); err != nil {
 		return false, err.Error()
@@ -197,9 +553,16 @@ func (a *App) UpdateSettings(s models.Settings) (bool, string) {
 		}
 	}
 	// Ensure watcher reflects latest settings. If running, restart with new config; if stopped, just update config.
+	// Filter rules only re-tag records in memory, so they never require a restart.
 	if a.watcher != nil {
 		cfg := a.makeWatcherConfig(a.settings.StatsDir)
-		if a.watcher.IsRunning() {
+		a.watcher.SetFilters(filterSet)
+		a.watcher.SetAnomalyExclusion(a.settings.ExcludeAnomalousRuns)
+		a.watcher.SetCurrentVersionOnly(a.settings.CurrentVersionOnly)
+		switch {
+		case cfg == a.watcher.Config():
+			// Ingestion settings unchanged: keep parsed records, they were just re-tagged
+		case a.watcher.IsRunning():
 			// Stop, reconfigure, restart
 			_ = a.watcher.Stop()
 			if err := a.watcher.UpdateConfig(cfg); err != nil {
@@ -214,7 +577,7 @@ func (a *App) UpdateSettings(s models.Settings) (bool, string) {
 				runtime.LogErrorf(a.ctx, "Watcher restart error: %v", err)
 				return false, err.Error()
 			}
-		} else {
+		default:
 			// Not running: just update cfg so the next start uses it
 			if err := a.watcher.UpdateConfig(cfg); err != nil {
 				return false, err.Error()
@@ -249,12 +612,55 @@ func (a *App) SetFavoriteBenchmarks(ids []string) (bool, string) {
 	return true, "ok"
 }
 
+// SetHiddenScenarios replaces the list of hidden scenario names and re-tags records in memory.
+func (a *App) SetHiddenScenarios(names []string) (bool, string) {
+	set, err := filters.Compile(a.settings.ScenarioFilters, names)
+	if err != nil {
+		return false, err.Error()
+	}
+	a.settings.HiddenScenarios = append([]string(nil), names...)
+	if err := appsettings.Save(a.settings); err != nil {
+		return false, err.Error()
+	}
+	if a.watcher != nil {
+		a.watcher.SetFilters(set)
+	}
+	return true, "ok"
+}
+
 // ResetSettings resets settings to application defaults and applies them immediately.
 func (a *App) ResetSettings() (bool, string) {
 	// Delegate to UpdateSettings to reuse application logic (save, mouse, watcher, traces)
 	return a.UpdateSettings(appsettings.Default())
 }
 
+// --- Stats archive IPC ---
+
+// ArchiveOldStats moves stats files played more than olderThanDays ago into compressed
+// monthly archives. With dryRun set, nothing is moved and the result reports what would be.
+func (a *App) ArchiveOldStats(olderThanDays int, dryRun bool) (models.ArchiveResult, error) {
+	if olderThanDays <= 0 {
+		return models.ArchiveResult{DryRun: dryRun}, errors.New("olderThanDays must be positive")
+	}
+	cutoff := time.Now().AddDate(0, 0, -olderThanDays)
+	res, err := archive.Archive(a.statsDir(), cutoff, dryRun)
+	if err == nil && !dryRun && res.Files > 0 {
+		runtime.LogInfof(a.ctx, "archived %d stats files (%d bytes) into %d monthly archives", res.Files, res.Bytes, len(res.Months))
+	}
+	return res, err
+}
+
+// RestoreArchivedStats extracts archived stats files back into the stats directory.
+// months selects YYYY-MM buckets to restore; empty restores everything.
+func (a *App) RestoreArchivedStats(months []string, dryRun bool) (models.ArchiveResult, error) {
+	return archive.Restore(a.statsDir(), months, dryRun)
+}
+
+// GetArchivedStats returns the index of archived stats files.
+func (a *App) GetArchivedStats() ([]models.ArchiveEntry, error) {
+	return archive.Entries()
+}
+
 // --- App metadata ---
 
 // GetVersion returns the current application version.
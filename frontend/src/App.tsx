@@ -2,7 +2,7 @@ import { useEffect, useRef, useState } from 'react'
 import { BrowserOpenURL, EventsOn } from '../wailsjs/runtime'
 import { navigate } from './hooks/useRoute'
 import { StoreProvider, useStore } from './hooks/useStore'
-import { checkForUpdates, downloadAndInstallUpdate, getRecentScenarios, getSettings, getVersion, startWatcher } from './lib/internal'
+import { checkForUpdates, downloadAndInstallUpdate, getRecentScenarios, getSessions, getVersion, startWatcher } from './lib/internal'
 import { applyTheme, getSavedTheme } from './lib/theme'
 import { BenchmarksPage } from './pages/Benchmarks'
 import { ScenariosPage } from './pages/Scenarios'
@@ -107,7 +107,8 @@ function Shell() {
   const incNew = useStore(s => s.incNew)
   const resetNew = useStore(s => s.resetNew)
   const setScenarios = useStore(s => s.setScenarios)
-  const setSessionGap = useStore(s => s.setSessionGap)
+  const setSessions = useStore(s => s.setSessions)
+  const upsertSession = useStore(s => s.upsertSession)
   const [path, setPath] = useState(window.location.pathname)
   const startedRef = useRef(false)
 
@@ -123,11 +124,11 @@ function Shell() {
       .then((arr) => { setScenarios(arr) })
       .catch((err: unknown) => console.warn('GetRecentScenarios failed:', err))
 
-    // Initialize session gap for session grouping
-    getSettings()
-      .then((s) => { if (s && typeof s.sessionGapMinutes === 'number') setSessionGap(s.sessionGapMinutes) })
-      .catch(() => { })
-  }, [setScenarios, setSessionGap])
+    // Sessions are grouped by the backend; Session* events keep them current
+    getSessions()
+      .then((arr) => { setSessions(arr) })
+      .catch((err: unknown) => console.warn('GetSessions failed:', err))
+  }, [setScenarios, setSessions])
 
   // Subscriptions effect: keep separate so it can cleanup/re-subscribe if handlers change
   useEffect(() => {
@@ -152,6 +153,18 @@ function Shell() {
       resetNew()
     })
 
+    const onSession = (data: any) => {
+      const sess = data && data.id && Array.isArray(data.runIds) ? data : null
+      if (sess) upsertSession(sess)
+    }
+    const offSessions = ['SessionStarted', 'SessionUpdated', 'SessionEnded'].map(name => EventsOn(name, onSession))
+    // Filter rules, the session gap or a rebuild regrouped every session
+    const offReset = EventsOn('SessionsReset', () => {
+      getSessions()
+        .then((arr) => { setSessions(arr) })
+        .catch((err: unknown) => console.warn('GetSessions failed:', err))
+    })
+
     const onPop = () => setPath(window.location.pathname)
     window.addEventListener('popstate', onPop)
 
@@ -159,9 +172,11 @@ function Shell() {
       try { off() } catch (e) { /* ignore */ }
       try { offUpd() } catch (e) { /* ignore */ }
       try { offWatcher() } catch (e) { /* ignore */ }
+      for (const o of offSessions) { try { o() } catch (e) { /* ignore */ } }
+      try { offReset() } catch (e) { /* ignore */ }
       window.removeEventListener('popstate', onPop)
     }
-  }, [addScenario, updateScenario, incNew, setScenarios, resetNew])
+  }, [addScenario, updateScenario, incNew, setScenarios, resetNew, setSessions, upsertSession])
 
   return (
     <div className="flex flex-col h-screen bg-[var(--bg-primary)] text-[var(--text-primary)]">
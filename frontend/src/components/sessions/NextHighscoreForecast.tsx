@@ -1,11 +1,42 @@
-import { useMemo } from 'react';
-import { predictNextHighscore } from '../../lib/analysis';
-import type { ScenarioRecord } from '../../types/ipc';
+import { useEffect, useState } from 'react';
+import { getHighscorePrediction } from '../../lib/internal';
+import type { HighscorePrediction, ScenarioRecord } from '../../types/ipc';
 import { InfoBox } from '../shared/InfoBox';
 import { PreviewTag } from '../shared/PreviewTag';
 
+function humanizeETA(ms: number): string {
+  if (!Number.isFinite(ms) || ms <= 0) return 'soon'
+  const totalMin = Math.round(ms / (60 * 1000))
+  const days = Math.floor(totalMin / (60 * 24))
+  const hours = Math.floor((totalMin % (60 * 24)) / 60)
+  const mins = totalMin % 60
+  if (days <= 0) {
+    if (hours <= 0) return `${mins}m`
+    // under a day, show Hh Mm when there are minutes
+    return mins ? `${hours}h ${mins}m` : `${hours}h`
+  }
+  if (days < 7) return mins ? `${days}d ${hours}h ${mins}m` : (hours ? `${days}d ${hours}h` : `${days}d`)
+  const weeks = Math.floor(days / 7)
+  const remDays = days % 7
+  return remDays ? `${weeks}w ${remDays}d` : `${weeks}w`
+}
+
+const EMPTY: HighscorePrediction = {
+  scenario: '', predictedScore: 0, runsExpected: 0, runsLo: 0, runsHi: 0, optPauseHours: 0, confidence: 'low',
+  confidenceScore: 0, sample: 0, best: 0, lastScore: 0, lastPlayedDays: 0, slopePerDay: 0, slopePerRun: 0,
+}
+
 export function NextHighscoreForecast({ items, scenarioName }: { items: ScenarioRecord[]; scenarioName: string }) {
-  const pred = useMemo(() => predictNextHighscore(items, scenarioName), [items, scenarioName])
+  const [pred, setPred] = useState<HighscorePrediction>(EMPTY)
+  // The forecast runs in the backend over full history; items only signal that new runs arrived.
+  useEffect(() => {
+    let cancelled = false
+    getHighscorePrediction(scenarioName)
+      .then(p => { if (!cancelled) setPred(p) })
+      .catch(() => { if (!cancelled) setPred({ ...EMPTY, reason: 'Unavailable' }) })
+    return () => { cancelled = true }
+  }, [items, scenarioName])
+  const etaHuman = pred.eta ? humanizeETA(Date.parse(pred.eta) - Date.now()) : 'unknown'
 
   const badge = (c: 'low' | 'med' | 'high') => {
     const cls = c === 'high' ? 'bg-emerald-500/20 text-emerald-300 border-emerald-500/40'
@@ -35,16 +66,14 @@ export function NextHighscoreForecast({ items, scenarioName }: { items: Scenario
         <div className="min-w-0">
           <div className="text-[var(--text-secondary)] text-xs">ETA to next high score</div>
           <div className="mt-0.5 flex items-center gap-2 text-base md:text-lg font-medium leading-tight min-w-0 text-[var(--text-primary)]">
-            {pred.runsExpected ? (
+            {pred.runsExpected > 0 ? (
               <>
-                <span className="truncate">~{pred.runsLo ?? pred.runsExpected}–{pred.runsHi ?? pred.runsExpected} runs</span>
-                {pred.optPauseHours != null && (
+                <span className="truncate">~{pred.runsLo || pred.runsExpected}–{pred.runsHi || pred.runsExpected} runs</span>
+                {pred.optPauseHours > 0 && (
                   <span className="text-xs text-[var(--text-secondary)]">avg pause ~{Math.max(1, Math.round(pred.optPauseHours * 60))}m</span>
                 )}
-                <span className="hidden md:inline text-xs text-[var(--text-secondary)]">(≈ {pred.etaHuman})</span>
+                <span className="hidden md:inline text-xs text-[var(--text-secondary)]">(≈ {etaHuman})</span>
               </>
-            ) : pred.etaTs ? (
-              <span className="truncate">~{pred.etaHuman}</span>
             ) : (
               <span className="text-[var(--text-secondary)] truncate">{pred.reason ?? 'Unknown'}</span>
             )}
@@ -55,7 +84,7 @@ export function NextHighscoreForecast({ items, scenarioName }: { items: Scenario
         <div className="grid grid-cols-2 md:grid-cols-4 gap-x-4 gap-y-1 text-xs shrink-0">
           <div className="min-w-0">
             <div className="text-[var(--text-secondary)]">Best</div>
-            <div className="font-medium truncate text-[var(--text-primary)]">{Math.round(pred.best)}</div>
+            <div className="font-medium truncate text-[var(--text-primary)]">{Math.round(pred.best)}{pred.predictedScore > 0 && <span className="text-[var(--text-secondary)]"> → {Math.round(pred.predictedScore)}</span>}</div>
           </div>
           <div className="min-w-0">
             <div className="text-[var(--text-secondary)]">Last</div>
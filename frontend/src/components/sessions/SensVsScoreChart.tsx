@@ -1,13 +1,25 @@
-import { useMemo } from 'react'
+import { useEffect, useMemo, useState } from 'react'
 import { Scatter } from 'react-chartjs-2'
 import { ChartBox } from '..'
 import { useChartTheme } from '../../hooks/useChartTheme'
+import { getSensitivityAnalysisFor } from '../../lib/internal'
 import { getScenarioName } from '../../lib/utils'
-import type { ScenarioRecord } from '../../types/ipc'
+import type { ScenarioRecord, SensitivityAnalysis } from '../../types/ipc'
 import { PreviewTag } from '../shared/PreviewTag'
 
 export function SensVsScoreChart({ items, scenarioName }: { items: ScenarioRecord[]; scenarioName: string }) {
   const colors = useChartTheme()
+  const [analysis, setAnalysis] = useState<SensitivityAnalysis | null>(null)
+
+  // Recommendation comes from the backend over full history; the run count only signals new runs.
+  const runCount = useMemo(() => items.filter(it => getScenarioName(it) === scenarioName).length, [items, scenarioName])
+  useEffect(() => {
+    let cancelled = false
+    getSensitivityAnalysisFor(scenarioName)
+      .then(a => { if (!cancelled) setAnalysis(a) })
+      .catch(() => { if (!cancelled) setAnalysis(null) })
+    return () => { cancelled = true }
+  }, [scenarioName, runCount])
 
   const points = useMemo(() => {
     const pts: Array<{ x: number; y: number; i: number }> = []
@@ -118,12 +130,20 @@ export function SensVsScoreChart({ items, scenarioName }: { items: ScenarioRecor
           <li>We only plot runs where sensitivity could be computed. Unsupported scales appear as cm/360 = 0 and are omitted.</li>
           <li>Lower cm/360 means higher sensitivity. Try comparing clusters to find your sweet spot.</li>
           <li>Point color shows recency: greyish = older, amber = newer.</li>
+          <li>The recommended range compares runs after removing your improvement over time, so later sensitivities are not favored just because you got better.</li>
         </ul>
       </div>}
       height={300}
     >
-      <div className="h-full">
-        <Scatter data={data as any} options={options as any} />
+      <div className="h-full flex flex-col">
+        <div className="text-xs text-[var(--text-secondary)] mb-1 truncate">
+          {analysis?.sufficient
+            ? <>Best range: <span className="text-[var(--text-primary)]">{analysis.bestLo.toFixed(1)}–{analysis.bestHi.toFixed(1)} cm/360</span> ({analysis.gain >= 0 ? '+' : ''}{(analysis.gain * 100).toFixed(1)}% vs trend, {analysis.confidence} confidence)</>
+            : (analysis?.reason ?? 'No recommendation yet')}
+        </div>
+        <div className="flex-1 min-h-0">
+          <Scatter data={data as any} options={options as any} />
+        </div>
       </div>
     </ChartBox>
   )
@@ -1,12 +1,12 @@
 import React, { createContext, useCallback, useContext, useMemo, useReducer } from 'react'
 import type { Session } from '../types/domain'
-import type { ScenarioRecord } from '../types/ipc'
+import type { ScenarioRecord, SessionSummary } from '../types/ipc'
 
 type State = {
   scenarios: ScenarioRecord[]
   newScenarios: number
-  sessions: Session[]
-  sessionGapMinutes: number
+  // Sessions as grouped by the backend, newest first
+  sessionSummaries: SessionSummary[]
 }
 
 type Action =
@@ -15,45 +15,54 @@ type Action =
   | { type: 'update'; item: ScenarioRecord }
   | { type: 'incNew' }
   | { type: 'resetNew' }
-  | { type: 'setGap'; minutes: number }
+  | { type: 'setSessions'; items: SessionSummary[] }
+  | { type: 'upsertSession'; item: SessionSummary }
 
-const initial: State = { scenarios: [], newScenarios: 0, sessions: [], sessionGapMinutes: 30 }
+const initial: State = { scenarios: [], newScenarios: 0, sessionSummaries: [] }
 
 function reducer(state: State, action: Action): State {
   switch (action.type) {
     case 'set':
-      return { ...state, scenarios: action.items ?? [], sessions: groupSessions(action.items ?? [], state.sessionGapMinutes) }
+      return { ...state, scenarios: action.items ?? [] }
     case 'add':
-      return { ...state, scenarios: [action.item, ...state.scenarios], sessions: groupSessions([action.item, ...state.scenarios], state.sessionGapMinutes) }
+      return { ...state, scenarios: [action.item, ...state.scenarios] }
     case 'update': {
       const idx = state.scenarios.findIndex(s => s.filePath === action.item.filePath)
       if (idx === -1) {
         // if unknown, append without incrementing newScenarios
-        const next = [action.item, ...state.scenarios]
-        return { ...state, scenarios: next, sessions: groupSessions(next, state.sessionGapMinutes) }
+        return { ...state, scenarios: [action.item, ...state.scenarios] }
       }
       const next = [...state.scenarios]
       next[idx] = action.item
-      return { ...state, scenarios: next, sessions: groupSessions(next, state.sessionGapMinutes) }
+      return { ...state, scenarios: next }
     }
     case 'incNew':
       return { ...state, newScenarios: state.newScenarios + 1 }
     case 'resetNew':
       return { ...state, newScenarios: 0 }
-    case 'setGap':
-      return { ...state, sessionGapMinutes: Math.max(1, Math.floor(action.minutes)), sessions: groupSessions(state.scenarios, Math.max(1, Math.floor(action.minutes))) }
+    case 'setSessions':
+      return { ...state, sessionSummaries: action.items ?? [] }
+    case 'upsertSession': {
+      // A late run can regroup sessions, so drop any that shared a run with the update.
+      const ids = new Set(action.item.runIds ?? [])
+      const rest = state.sessionSummaries.filter(s => s.id !== action.item.id && !(s.runIds ?? []).some(id => ids.has(id)))
+      const next = [...rest, action.item].sort((a, b) => Date.parse(b.start) - Date.parse(a.start))
+      return { ...state, sessionSummaries: next }
+    }
     default:
       return state
   }
 }
 
-type Ctx = State & {
+type Ctx = Omit<State, 'sessionSummaries'> & {
+  sessions: Session[]
   setScenarios: (items: ScenarioRecord[]) => void
   addScenario: (item: ScenarioRecord) => void
   updateScenario: (item: ScenarioRecord) => void
   incNew: () => void
   resetNew: () => void
-  setSessionGap: (minutes: number) => void
+  setSessions: (items: SessionSummary[]) => void
+  upsertSession: (item: SessionSummary) => void
 }
 
 const StoreCtx = createContext<Ctx | null>(null)
@@ -67,17 +76,26 @@ export function StoreProvider({ children }: { children: React.ReactNode }) {
   const updateScenario = useCallback((item: ScenarioRecord) => dispatch({ type: 'update', item }), [dispatch])
   const incNew = useCallback(() => dispatch({ type: 'incNew' }), [dispatch])
   const resetNew = useCallback(() => dispatch({ type: 'resetNew' }), [dispatch])
-  const setSessionGap = useCallback((minutes: number) => dispatch({ type: 'setGap', minutes }), [dispatch])
+  const setSessions = useCallback((items: SessionSummary[]) => dispatch({ type: 'setSessions', items }), [dispatch])
+  const upsertSession = useCallback((item: SessionSummary) => dispatch({ type: 'upsertSession', item }), [dispatch])
+
+  // Hidden records (scenario filter rules) stay in state so rule changes apply in place,
+  // but consumers only ever see visible ones.
+  const visible = useMemo(() => state.scenarios.filter(s => !s.hidden), [state.scenarios])
+  const sessions = useMemo(() => joinSessions(state.sessionSummaries, visible), [state.sessionSummaries, visible])
 
   const value = useMemo<Ctx>(() => ({
-    ...state,
+    scenarios: visible,
+    newScenarios: state.newScenarios,
+    sessions,
     setScenarios,
     addScenario,
     updateScenario,
     incNew,
     resetNew,
-    setSessionGap,
-  }), [state, setScenarios, addScenario, updateScenario, incNew, resetNew, setSessionGap])
+    setSessions,
+    upsertSession,
+  }), [state.newScenarios, visible, sessions, setScenarios, addScenario, updateScenario, incNew, resetNew, setSessions, upsertSession])
   return <StoreCtx.Provider value={value}>{children}</StoreCtx.Provider>
 }
 
@@ -88,51 +106,15 @@ export function useStore<T>(selector: (s: Ctx) => T): T {
 }
 
 // --- Helpers ---
-function groupSessions(items: ScenarioRecord[], gapMinutes = 30): Session[] {
-  if (!Array.isArray(items) || items.length === 0) return []
-  // Ensure newest first
-  const sorted = [...items].sort((a, b) => endTs(b) - endTs(a))
-  const sessions: Session[] = []
-  let current: Session | null = null
-  for (const it of sorted) {
-    const t = endTs(it)
-    if (!current) {
-      current = { id: `sess-${t}`, start: startIso(it), end: endIso(it), items: [it] }
-      sessions.push(current)
-      continue
-    }
-    const last = current.items[current.items.length - 1]
-    const dt = Math.abs(endTs(last) - t)
-    if (dt <= gapMinutes * 60 * 1000) {
-      current.items.push(it)
-      // Maintain session bounds: start = earliest scenario start, end = latest scenario end
-      const curStartMs = Date.parse(current.start)
-      const curEndMs = Date.parse(current.end)
-      const itStartMs = startTs(it)
-      const itEndMs = endTs(it)
-      current.start = curStartMs <= itStartMs ? current.start : startIso(it)
-      current.end = curEndMs >= itEndMs ? current.end : endIso(it)
-    } else {
-      current = { id: `sess-${t}`, start: startIso(it), end: endIso(it), items: [it] }
-      sessions.push(current)
-    }
+// joinSessions attaches the loaded records to each backend session by run ID. Sessions
+// whose runs are all outside the loaded records are left out.
+function joinSessions(summaries: SessionSummary[], items: ScenarioRecord[]): Session[] {
+  const byId = new Map(items.map(it => [it.fileName, it]))
+  const out: Session[] = []
+  for (const s of summaries) {
+    // Records are shown newest first, like the session list
+    const recs = (s.runIds ?? []).map(id => byId.get(id)).filter((it): it is ScenarioRecord => !!it).reverse()
+    if (recs.length > 0) out.push({ id: s.id, start: s.start, end: s.end, items: recs })
   }
-  return sessions
-}
-
-// --- Timestamp helpers (simplified: fixed keys, no fallbacks) ---
-function endIso(s: ScenarioRecord): string {
-  return s.stats['Date Played'] as string
-}
-function endTs(s: ScenarioRecord): number {
-  return Date.parse(endIso(s))
-}
-function startIso(s: ScenarioRecord): string {
-  const end = endIso(s)
-  const datePart = end.split('T')[0]
-  const time = s.stats['Challenge Start'] as string
-  return `${datePart}T${time}Z`
-}
-function startTs(s: ScenarioRecord): number {
-  return Date.parse(startIso(s))
+  return out
 }
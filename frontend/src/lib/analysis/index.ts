@@ -1,4 +1,3 @@
 export * from './metrics'
-export * from './prediction'
 export * from './scenario'
 export * from './sessionLength'
@@ -1,22 +1,56 @@
 import {
+  AddGoal as _AddGoal,
+  AddRoutine as _AddRoutine,
   CheckForUpdates as _CheckForUpdates,
+  CompareRuns as _CompareRuns,
+  DeleteGoal as _DeleteGoal,
+  DeleteRoutine as _DeleteRoutine,
   DownloadAndInstallUpdate as _DownloadAndInstallUpdate,
+  GetActivityHeatmap as _GetActivityHeatmap,
   GetBenchmarkProgress as _GetBenchmarkProgress,
   GetBenchmarks as _GetBenchmarks,
+  GetConvertedTrace as _GetConvertedTrace,
   GetDefaultSettings as _GetDefaultSettings,
+  GetFatigueProfiles as _GetFatigueProfiles,
   GetFavoriteBenchmarks as _GetFavoriteBenchmarks,
+  GetFlickAnalysis as _GetFlickAnalysis,
+  GetGoalStatus as _GetGoalStatus,
+  GetGoals as _GetGoals,
+  GetHighscorePrediction as _GetHighscorePrediction,
+  GetPersonalBestHistory as _GetPersonalBestHistory,
+  GetPersonalBests as _GetPersonalBests,
+  GetPracticeVolume as _GetPracticeVolume,
   GetRecentScenarios as _GetRecentScenarios,
+  GetRoutineStatus as _GetRoutineStatus,
+  GetRoutines as _GetRoutines,
+  GetRunAnomalies as _GetRunAnomalies,
+  GetScenarioLineage as _GetScenarioLineage,
+  GetScenarioSummary as _GetScenarioSummary,
+  GetSessionLengthRecommendations as _GetSessionLengthRecommendations,
+  GetSensitivityAnalysis as _GetSensitivityAnalysis,
+  GetSensitivityAnalysisFor as _GetSensitivityAnalysisFor,
+  GetSessions as _GetSessions,
   GetSettings as _GetSettings,
+  GetSetupImpact as _GetSetupImpact,
+  GetSkillRatings as _GetSkillRatings,
+  GetSmoothnessTrend as _GetSmoothnessTrend,
+  GetTimeOfDayAnalysis as _GetTimeOfDayAnalysis,
+  GetTrace as _GetTrace,
+  GetTraceKinematics as _GetTraceKinematics,
+  GetTrendFindings as _GetTrendFindings,
   GetVersion as _GetVersion,
   LaunchKovaaksScenario as _LaunchKovaaksScenario,
+  PlayNextRoutineScenario as _PlayNextRoutineScenario,
   ResetSettings as _ResetSettings,
   SetFavoriteBenchmarks as _SetFavoriteBenchmarks,
   StartWatcher as _StartWatcher,
   StopWatcher as _StopWatcher,
+  UpdateGoal as _UpdateGoal,
+  UpdateRoutine as _UpdateRoutine,
   UpdateSettings as _UpdateSettings
 } from '../../wailsjs/go/main/App'
 import type { models } from '../../wailsjs/go/models'
-import type { Benchmark, ScenarioRecord, Settings, UpdateInfo } from '../types/ipc'
+import type { Benchmark, CategoryRating, DayActivity, FatigueProfile, FlickAnalysis, Goal, GoalStatus, HighscorePrediction, PersonalBest, PracticeVolume, Routine, RoutineStatus, RunAnomaly, RunComparison, ScenarioLineage, ScenarioRecord, ScenarioSummary, SensitivityAnalysis, SessionLengthRecommendation, SessionSummary, Settings, SetupAnalysis, SmoothnessTrend, TimeOfDayAnalysis, TraceKinematics, TracePoint, TraceSlice, TraceUnit, TrendFinding, UpdateInfo } from '../types/ipc'
 
 export type { models }
 
@@ -41,6 +75,180 @@ export async function getRecentScenarios(limit: number): Promise<ScenarioRecord[
   return (Array.isArray(res) ? res : []) as unknown as ScenarioRecord[]
 }
 
+export async function getSessions(limit = 0): Promise<SessionSummary[]> {
+  const res = await _GetSessions(limit)
+  return (Array.isArray(res) ? res : []) as unknown as SessionSummary[]
+}
+
+export async function getPersonalBests(): Promise<PersonalBest[]> {
+  const res = await _GetPersonalBests()
+  return (Array.isArray(res) ? res : []) as unknown as PersonalBest[]
+}
+
+export async function getPersonalBestHistory(scenario: string): Promise<PersonalBest[]> {
+  const res = await _GetPersonalBestHistory(String(scenario || ''))
+  return (Array.isArray(res) ? res : []) as unknown as PersonalBest[]
+}
+
+export async function getScenarioSummary(name: string): Promise<ScenarioSummary> {
+  const res = await _GetScenarioSummary(String(name || ''))
+  return res as unknown as ScenarioSummary
+}
+
+export async function getScenarioLineage(name: string): Promise<ScenarioLineage> {
+  const res = await _GetScenarioLineage(String(name || ''))
+  return res as unknown as ScenarioLineage
+}
+
+export async function getHighscorePrediction(name: string): Promise<HighscorePrediction> {
+  const res = await _GetHighscorePrediction(String(name || ''))
+  return res as unknown as HighscorePrediction
+}
+
+export async function getSessionLengthRecommendations(): Promise<SessionLengthRecommendation[]> {
+  const res = await _GetSessionLengthRecommendations()
+  return (Array.isArray(res) ? res : []) as unknown as SessionLengthRecommendation[]
+}
+
+export async function getFatigueProfiles(): Promise<FatigueProfile[]> {
+  const res = await _GetFatigueProfiles()
+  return (Array.isArray(res) ? res : []) as unknown as FatigueProfile[]
+}
+
+export async function getSensitivityAnalysis(): Promise<SensitivityAnalysis[]> {
+  const res = await _GetSensitivityAnalysis()
+  return (Array.isArray(res) ? res : []) as unknown as SensitivityAnalysis[]
+}
+
+export async function getSensitivityAnalysisFor(name: string): Promise<SensitivityAnalysis> {
+  const res = await _GetSensitivityAnalysisFor(String(name || ''))
+  return res as unknown as SensitivityAnalysis
+}
+
+export async function getSkillRatings(): Promise<CategoryRating[]> {
+  const res = await _GetSkillRatings()
+  return (Array.isArray(res) ? res : []) as unknown as CategoryRating[]
+}
+
+export async function getTimeOfDayAnalysis(): Promise<TimeOfDayAnalysis> {
+  const res = await _GetTimeOfDayAnalysis()
+  return res as unknown as TimeOfDayAnalysis
+}
+
+export async function getTrendFindings(): Promise<TrendFinding[]> {
+  const res = await _GetTrendFindings()
+  return (Array.isArray(res) ? res : []) as unknown as TrendFinding[]
+}
+
+export async function getGoals(): Promise<GoalStatus[]> {
+  const res = await _GetGoals()
+  return (Array.isArray(res) ? res : []) as unknown as GoalStatus[]
+}
+
+export async function getGoalStatus(id: string): Promise<GoalStatus> {
+  const res = await _GetGoalStatus(id)
+  return res as unknown as GoalStatus
+}
+
+export async function addGoal(goal: Pick<Goal, 'scenario' | 'metric' | 'target' | 'deadline'>): Promise<Goal> {
+  const res = await _AddGoal(goal as unknown as models.Goal)
+  return res as unknown as Goal
+}
+
+export async function updateGoal(goal: Goal): Promise<void> {
+  const res = await _UpdateGoal(goal as unknown as models.Goal)
+  if (res !== true) throw new Error(typeof res === 'string' ? res : 'UpdateGoal failed')
+}
+
+export async function deleteGoal(id: string): Promise<void> {
+  const res = await _DeleteGoal(id)
+  if (res !== true) throw new Error(typeof res === 'string' ? res : 'DeleteGoal failed')
+}
+
+export async function getRoutines(): Promise<RoutineStatus[]> {
+  const res = await _GetRoutines()
+  return (Array.isArray(res) ? res : []) as unknown as RoutineStatus[]
+}
+
+export async function getRoutineStatus(id: string): Promise<RoutineStatus> {
+  const res = await _GetRoutineStatus(id)
+  return res as unknown as RoutineStatus
+}
+
+export async function addRoutine(routine: Pick<Routine, 'name' | 'items' | 'days'>): Promise<Routine> {
+  const res = await _AddRoutine(routine as unknown as models.Routine)
+  return res as unknown as Routine
+}
+
+export async function updateRoutine(routine: Routine): Promise<void> {
+  const res = await _UpdateRoutine(routine as unknown as models.Routine)
+  if (res !== true) throw new Error(typeof res === 'string' ? res : 'UpdateRoutine failed')
+}
+
+export async function deleteRoutine(id: string): Promise<void> {
+  const res = await _DeleteRoutine(id)
+  if (res !== true) throw new Error(typeof res === 'string' ? res : 'DeleteRoutine failed')
+}
+
+// Launch the routine's next unfinished scenario for today
+export async function playNextRoutineScenario(id: string): Promise<void> {
+  const res = await _PlayNextRoutineScenario(id)
+  if (res !== true) throw new Error(typeof res === 'string' ? res : 'PlayNextRoutineScenario failed')
+}
+
+export async function getPracticeVolume(): Promise<PracticeVolume> {
+  const res = await _GetPracticeVolume()
+  return res as unknown as PracticeVolume
+}
+
+export async function getActivityHeatmap(days = 365): Promise<DayActivity[]> {
+  const res = await _GetActivityHeatmap(days)
+  return (Array.isArray(res) ? res : []) as unknown as DayActivity[]
+}
+
+export async function compareRuns(idA: string, idB: string): Promise<RunComparison> {
+  const res = await _CompareRuns(idA, idB)
+  return res as unknown as RunComparison
+}
+
+export async function getRunAnomalies(): Promise<RunAnomaly[]> {
+  const res = await _GetRunAnomalies()
+  return (Array.isArray(res) ? res : []) as unknown as RunAnomaly[]
+}
+
+export async function getSetupImpact(): Promise<SetupAnalysis> {
+  const res = await _GetSetupImpact()
+  return res as unknown as SetupAnalysis
+}
+
+export async function getFlickAnalysis(id: string, unit: TraceUnit = 'counts'): Promise<FlickAnalysis> {
+  const res = await _GetFlickAnalysis(String(id || ''), unit)
+  return res as unknown as FlickAnalysis
+}
+
+// getTrace fetches a run's mouse trace between from and to seconds (to <= 0 for the
+// end), downsampled to at most maxPoints samples.
+export async function getTrace(id: string, maxPoints = 0, from = 0, to = 0): Promise<TraceSlice> {
+  const res = await _GetTrace(String(id || ''), Math.floor(maxPoints), from, to)
+  const slice = res as unknown as TraceSlice
+  return { ...slice, points: Array.isArray(slice?.points) ? slice.points : [] }
+}
+
+export async function getTraceKinematics(id: string, unit: TraceUnit = 'counts'): Promise<TraceKinematics> {
+  const res = await _GetTraceKinematics(String(id || ''), unit)
+  return res as unknown as TraceKinematics
+}
+
+export async function getSmoothnessTrend(scenario: string): Promise<SmoothnessTrend> {
+  const res = await _GetSmoothnessTrend(String(scenario || ''))
+  return res as unknown as SmoothnessTrend
+}
+
+export async function getConvertedTrace(id: string, unit: Exclude<TraceUnit, 'counts'>): Promise<TracePoint[]> {
+  const res = await _GetConvertedTrace(String(id || ''), unit)
+  return (Array.isArray(res) ? res : []) as unknown as TracePoint[]
+}
+
 export async function getSettings(): Promise<Settings> {
   const s = await _GetSettings()
   return s as unknown as Settings
@@ -1,9 +1,34 @@
-import type { ScenarioRecord } from '../../../types/ipc';
+import { useEffect, useState } from 'react';
 import { TraceViewer } from '../../../components/scenarios/TraceViewer';
+import { getTrace } from '../../../lib/internal';
 import type { Point } from '../../../types/domain';
+import type { ScenarioRecord } from '../../../types/ipc';
+
+// Enough samples to draw a smooth path while keeping the IPC payload small.
+const MAX_POINTS = 6000
 
 export function MouseTraceTab({ item }: { item: ScenarioRecord }) {
-  const points = Array.isArray(item.mouseTrace) ? (item.mouseTrace as Point[]) : []
+  const [points, setPoints] = useState<Point[] | null>(null)
+  const [error, setError] = useState<string | null>(null)
+
+  useEffect(() => {
+    let isMounted = true
+    setPoints(null)
+    setError(null)
+    if (!item.tracePoints) {
+      setPoints([])
+      return
+    }
+    getTrace(item.fileName, MAX_POINTS)
+      .then(slice => { if (isMounted) setPoints(slice.points as Point[]) })
+      .catch(e => { if (isMounted) setError(String(e?.message || e)) })
+    return () => { isMounted = false }
+  }, [item.fileName, item.tracePoints])
+
+  if (error) return <div className="text-sm text-red-400">{error}</div>
+  if (points === null) {
+    return <div className="text-sm text-[var(--text-secondary)]">Loading mouse trace…</div>
+  }
   if (points.length === 0) {
     return (
       <div className="text-sm text-[var(--text-secondary)]">
@@ -8,6 +8,7 @@ const CATEGORY_DEFS: Record<string, StatKey[]> = {
   'Overview': ['Score', 'Kills', 'Hit Count', 'Accuracy'],
   'Accuracy Details': ['Hit Count', 'Miss Count', 'Total Overshots', 'Damage Done', 'Damage Taken'],
   'Timing': ['Fight Time', 'Time Remaining', 'Avg TTK', 'Real Avg TTK', 'Pause Count', 'Pause Duration', 'Challenge Start'],
+  'Consistency': ['Kill Interval Variance', 'Kill Interval CV', 'Slowest 10% Kill Interval', 'First Half Avg Interval', 'Second Half Avg Interval', 'Pace Change', 'Longest Fast Kill Streak', 'Overshot Kills', 'Cheated Kills'],
   'Controls': ['Sens Scale', 'Sens Increment', 'Horiz Sens', 'Vert Sens', 'DPI', 'cm/360'],
   'Display': ['FOV', 'FOVScale', 'Resolution', 'Hide Gun', 'Crosshair', 'Crosshair Scale', 'Crosshair Color'],
   'Technical': ['Input Lag', 'Max FPS (config)', 'Avg FPS', 'Resolution Scale'],
@@ -55,6 +56,12 @@ function formatValue(key: string, raw: unknown): React.ReactNode {
   if ((key.includes('TTK') || key === 'Fight Time' || key === 'Pause Duration' || key === 'Time Remaining') && isNumber(raw)) {
     return formatSeconds(raw, 3)
   }
+  if (key.endsWith('Interval') && isNumber(raw)) {
+    return formatSeconds(raw, 3)
+  }
+  if ((key === 'Kill Interval CV' || key === 'Pace Change') && isNumber(raw)) {
+    return formatPercent(raw * 100, 1)
+  }
   if (key === 'Resolution Scale' && isNumber(raw)) {
     return formatPercent(raw, 0)
   }
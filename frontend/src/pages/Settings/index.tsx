@@ -1,13 +1,11 @@
 import { useEffect, useState } from 'react'
 import { BrowserOpenURL } from '../../../wailsjs/runtime'
 import { Button, Dropdown } from '../../components'
-import { useStore } from '../../hooks/useStore'
 import { checkForUpdates, downloadAndInstallUpdate, getSettings, getVersion, resetSettings, updateSettings } from '../../lib/internal'
 import { applyTheme, getSavedTheme, setTheme, THEMES, type Theme } from '../../lib/theme'
 import type { Settings, UpdateInfo } from '../../types/ipc'
 
 export function SettingsPage() {
-  const setSessionGap = useStore(s => s.setSessionGap)
   const [steamDir, setSteamDir] = useState('')
   const [steamIdOverride, setSteamIdOverride] = useState('')
   const [statsPath, setStatsPath] = useState('')
@@ -50,7 +48,6 @@ export function SettingsPage() {
     try {
       await updateSettings(payload)
       setTheme(theme)
-      setSessionGap(gap)
     } catch (e) {
       console.error('UpdateSettings error:', e)
     }
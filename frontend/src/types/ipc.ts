@@ -3,7 +3,12 @@ export interface ScenarioRecord {
   fileName: string
   stats: Record<string, any>
   events: string[][]
-  mouseTrace?: Array<{ ts: string; x: number; y: number }>
+  // Samples in the run's mouse trace; fetch them with getTrace
+  tracePoints?: number
+  // Set when the record matches the user's scenario filter rules
+  hidden?: boolean
+  // Reason codes when the run's technical stats deviate from the player's norm
+  anomalies?: AnomalyCode[]
 }
 
 export interface BenchmarkDifficulty {
@@ -36,6 +41,18 @@ export interface Settings {
   mouseTrackingEnabled?: boolean
   mouseBufferMinutes?: number
   maxExistingOnStart?: number
+  scenarioFilters?: ScenarioFilter[]
+  hiddenScenarios?: string[]
+  excludeAnomalousRuns?: boolean
+  currentVersionOnly?: boolean
+}
+
+export interface ScenarioFilter {
+  action: 'include' | 'exclude'
+  pattern: string
+  regex?: boolean
+  mode?: string
+  minDurationSeconds?: number
 }
 
 export interface UpdateInfo {
@@ -45,3 +62,555 @@ export interface UpdateInfo {
   downloadUrl?: string
   releaseNotes?: string
 }
+
+// Backend-grouped session (see GetSessions and Session* events)
+export interface SessionSummary {
+  id: string
+  start: string
+  end: string
+  active: boolean
+  runs: number
+  runIds: string[]
+  scenarios: Array<{ name: string; runs: number; bestScore: number; avgScore: number; avgAccuracy: number }>
+  playSeconds: number
+  avgAccuracy: number
+}
+
+export interface PersonalBest {
+  scenario: string
+  runId: string
+  datePlayed: string
+  score: number
+  previousScore: number
+  margin: number
+  marginPct: number
+  first?: boolean
+}
+
+export interface Distribution {
+  count: number
+  min: number
+  max: number
+  mean: number
+  median: number
+  p90: number
+  stdDev: number
+}
+
+export interface ScenarioSummary {
+  scenario: string
+  count: number
+  playSeconds: number
+  bestScore: number
+  meanScore: number
+  medianScore: number
+  p90Score: number
+  accuracy: Distribution // 0..1
+  ttk: Distribution // seconds
+  trendSlope: number // score change per run
+  trendRuns: number
+  firstPlayed: string
+  lastPlayed: string
+  daysSinceLastPlayed: number
+  versionBoundaries?: VersionBoundary[]
+}
+
+export interface HighscorePrediction {
+  scenario: string
+  predictedScore: number
+  runsExpected: number // 0 when no forecast could be made; see reason
+  runsLo: number
+  runsHi: number
+  eta?: string
+  optPauseHours: number
+  confidence: 'low' | 'med' | 'high'
+  confidenceScore: number
+  sample: number
+  best: number
+  lastScore: number
+  lastPlayedDays: number
+  slopePerDay: number
+  slopePerRun: number
+  reason?: string
+}
+
+export interface LengthStats {
+  runs: number
+  mean: number
+  min: number
+  max: number
+  std: number
+  p10: number
+  p90: number
+  count: number
+}
+
+export interface SessionLengthRecommendation {
+  scenario: string
+  sessions: number
+  sufficient: boolean // false when there are too few sessions; see reason
+  reason?: string
+  warmupRuns: number
+  optimalAvgRuns: number
+  optimalConsistentRuns: number
+  optimalHighscoreRuns: number
+  recommendedRuns: number
+  recommendedLo: number
+  recommendedHi: number
+  expectedBest: number[]
+  expectedAvg: LengthStats[]
+}
+
+// Curve values are relative to the scenario baseline (0 = baseline, 0.05 = 5% above)
+export interface CurvePoint {
+  x: number
+  mean: number
+  count: number
+}
+
+export interface FatigueProfile {
+  scenario: string
+  sessions: number
+  runs: number
+  baseline: number
+  sufficient: boolean
+  reason?: string
+  byPosition: CurvePoint[] | null
+  byElapsed: CurvePoint[] | null
+  warmupRuns: number
+  warmupMinutes: number
+  warmedLevel: number
+  fatigueOnsetRuns: number // 0 when no sustained decline
+  fatigueOnsetMinutes: number
+  fatigueDecline: number
+}
+
+// Payload of the FatigueDetected event
+export interface FatigueAlert {
+  sessionId: string
+  runId: string
+  runs: number
+  elapsedMinutes: number
+  warmedLevel: number
+  recentLevel: number
+  drop: number
+}
+
+export interface SensBin {
+  lo: number
+  hi: number
+  runs: number
+  mean: number // detrended score relative to typical (0.02 = 2% above trend)
+  stdErr: number
+}
+
+export interface SensitivityAnalysis {
+  group: string // scenario name or scenario type
+  kind: 'scenario' | 'type'
+  runs: number
+  bins: SensBin[] | null
+  sufficient: boolean
+  reason?: string
+  bestLo: number
+  bestHi: number
+  gain: number
+  confidence: 'low' | 'med' | 'high'
+  tStat: number
+}
+
+export interface TimeBucket {
+  key: number // hour 0-23 or weekday (0 = Sunday)
+  runs: number
+  meanPercentile: number // 0..1 within each scenario's history
+  scoreDelta: number // vs. usual (0.06 = 6% better)
+  stdErr: number
+  sufficient: boolean
+}
+
+export interface TimeWindow {
+  startHour: number
+  endHour: number // exclusive, wraps past midnight
+  runs: number
+  scoreDelta: number
+  tStat: number
+}
+
+export interface TimeOfDayAnalysis {
+  runs: number
+  scenarios: number
+  byHour: TimeBucket[] | null
+  byWeekday: TimeBucket[] | null
+  bestWindow?: TimeWindow
+  worstWindow?: TimeWindow
+  sufficient: boolean
+  reason?: string
+}
+
+export interface SetupChange {
+  key: string
+  before: string
+  after: string
+}
+
+export interface TrendFinding {
+  scenario: string
+  kind: 'plateau' | 'regression'
+  since: string // RFC3339
+  sessions: number
+  before: number // best score before a plateau, or mean session score before a regression
+  after: number
+  change: number // after vs. before (-0.05 = 5% lower)
+  tStat: number
+  setupChanges?: SetupChange[]
+}
+
+export interface RatingPoint {
+  date: string // YYYY-MM-DD
+  rating: number
+  rankProgress: number
+  runs: number
+}
+
+export interface CategoryRating {
+  category: string // Clicking, Tracking, Switching or Other
+  rating: number // 0..100, 50 = playing at your usual level
+  rankProgress: number // 3.4 = 40% of the way from rank 3 to 4; 0 when no thresholds are known
+  runs: number
+  rankedRuns: number
+  scenarios: number
+  history: RatingPoint[] | null
+}
+
+export type GoalMetric = 'score' | 'accuracy' | 'ttk'
+
+export interface Goal {
+  id: string
+  scenario: string
+  metric: GoalMetric // accuracy is 0..1; ttk is Real Avg TTK in seconds, lower is better
+  target: number
+  deadline?: string // RFC3339 or YYYY-MM-DD when creating
+  createdAt: string
+  achievedAt?: string
+  achievedRunId?: string
+}
+
+export interface GoalStatus {
+  goal: Goal
+  best: number
+  progress: number // 0..1
+  runs: number
+  achieved: boolean
+  eta?: string // RFC3339
+  onTrack: boolean
+  overdue: boolean
+}
+
+export interface RoutineItem {
+  scenario: string
+  runs: number
+}
+
+export interface Routine {
+  id: string
+  name: string
+  items: RoutineItem[]
+  days: number[] // weekdays, 0 = Sunday; empty = every day
+  createdAt: string
+}
+
+export interface RoutineItemProgress {
+  scenario: string
+  target: number
+  done: number
+}
+
+export interface RoutineStatus {
+  routine: Routine
+  scheduledToday: boolean
+  items: RoutineItemProgress[]
+  progress: number // 0..1 of today's required runs
+  completed: boolean
+  nextScenario?: string
+  streak: number
+  bestStreak: number
+  adherence: number // share of scheduled days completed over the last 28 days
+}
+
+export interface DayActivity {
+  date: string // YYYY-MM-DD
+  seconds: number
+  runs: number
+  scenarios: number
+  level: number // heatmap intensity, 0 = no practice, 1..4 by quartile
+}
+
+export interface WeekActivity {
+  week: string // Monday, YYYY-MM-DD
+  seconds: number
+  runs: number
+  scenarios: number
+  days: number
+}
+
+export interface PracticeVolume {
+  totalSeconds: number
+  totalRuns: number
+  scenarios: number
+  daysPractised: number
+  currentStreak: number
+  longestStreak: number
+  days: DayActivity[] | null
+  weeks: WeekActivity[] | null
+}
+
+export interface TraceMetrics {
+  points: number
+  durationSec: number
+  pathLength: number // in the analysis unit
+  meanSpeed: number // units per second
+  peakSpeed: number
+  directionChanges: number
+  // Straight-line distance of each movement between stationary periods, summed
+  displacement: number
+  pathEfficiency: number // 0..1
+  movements: number
+  meanAccel: number // units/s²
+  peakAccel: number
+  meanJerk: number // units/s³
+  peakJerk: number
+  movingSec: number
+  stationarySec: number
+}
+
+export interface KinematicSample {
+  t: number // seconds from the first sample
+  speed: number
+  accel: number
+  jerk: number
+}
+
+export type TraceUnit = 'counts' | 'deg' | 'cm'
+
+export interface TracePoint {
+  ts: string
+  x: number // degrees of yaw or cm, relative to the first sample
+  y: number
+}
+
+export interface MousePoint {
+  ts: string
+  x: number
+  y: number
+}
+
+export interface TraceSlice {
+  runId: string
+  total: number // samples in the whole trace
+  matched: number // samples in the window before downsampling
+  durationSec: number
+  fromSec: number
+  toSec: number
+  points: MousePoint[]
+}
+
+export interface TraceKinematics {
+  version: number
+  unit: TraceUnit
+  metrics: TraceMetrics
+  binSec: number
+  profile: KinematicSample[]
+  spectrum: TraceSpectrum
+}
+
+export interface SpectrumBin {
+  hz: number
+  power: number // units²/s² per Hz
+}
+
+export interface TraceSpectrum {
+  sampleHz: number
+  windows: number
+  bandLowHz: number
+  bandHighHz: number
+  tremorRms: number // units/s within the tremor band
+  tremorRatio: number // share of velocity power in the tremor band
+  peakTremorHz: number
+  smoothness: number // SPARC: 0 is perfectly smooth, more negative is jerkier
+  psd: SpectrumBin[]
+}
+
+export interface SmoothnessPoint {
+  runId: string
+  played: string // RFC3339
+  score: number
+  tremorRatio: number
+  smoothness: number
+}
+
+export interface SmoothnessTrend {
+  scenario: string
+  points: SmoothnessPoint[] // oldest first
+  tremorSlope: number // per run
+  smoothnessSlope: number
+}
+
+export interface RunRef {
+  id: string
+  scenario: string
+  played: string // RFC3339
+  score: number
+}
+
+export interface StatDiff {
+  key: string
+  a: number
+  b: number
+  delta: number // b - a
+  rel: number // b vs. a (0.05 = 5% higher)
+}
+
+export interface KillDelta {
+  index: number // 1-based kill number
+  a: number // seconds since run A started
+  b: number
+  delta: number // positive = run B behind
+}
+
+export interface TraceComparison {
+  // 'deg' when both runs' sensitivities are known
+  unit: TraceUnit
+  a: TraceMetrics
+  b: TraceMetrics
+  diffs: StatDiff[]
+}
+
+export interface RunComparison {
+  a: RunRef
+  b: RunRef
+  stats: StatDiff[] | null
+  kills: KillDelta[] | null
+  setup: SetupChange[] | null // before = run A, after = run B
+  trace?: TraceComparison
+}
+
+export type AnomalyCode = 'fps_drop' | 'time_dilation' | 'input_lag' | 'resolution_scale' | 'target_scale'
+
+export interface AnomalyReason {
+  code: AnomalyCode
+  stat: string
+  value: number
+  norm: number
+}
+
+export interface RunAnomaly {
+  id: string
+  scenario: string
+  played: string
+  score: number
+  reasons: AnomalyReason[]
+  excluded: boolean
+}
+
+export type SetupCategory = 'sensitivity' | 'fov' | 'crosshair' | 'resolution'
+
+export interface SetupEpoch {
+  index: number
+  start: string
+  end: string
+  runs: number
+  settings: Record<string, string>
+}
+
+export interface SetupImpact {
+  scenario: string
+  runsBefore: number
+  runsAfter: number
+  meanBefore: number
+  meanAfter: number
+  // Mean score the pre-change learning trend predicts after the change
+  expected: number
+  // Runs after the change relative to trend, as a fraction of the typical score
+  effect: number
+  effectSize: number
+  tStat: number
+  confidence: 'low' | 'med' | 'high'
+}
+
+export interface SetupChangePoint {
+  at: string
+  epoch: number
+  categories: SetupCategory[]
+  changes: SetupChange[]
+  impacts: SetupImpact[]
+  meanEffect: number
+}
+
+export interface SetupAnalysis {
+  epochs: SetupEpoch[]
+  changes: SetupChangePoint[]
+}
+
+export interface VersionSpan {
+  value: string
+  first: string
+  last: string
+  runs: number
+  bestScore: number
+  current: boolean
+}
+
+export interface VersionBoundary {
+  scenario: string
+  // 'hash' when the scenario was edited, 'game' for a game patch
+  kind: 'hash' | 'game'
+  runId: string
+  at: string
+  before: string
+  after: string
+}
+
+export interface ScenarioLineage {
+  scenario: string
+  hashes: VersionSpan[]
+  gameVersions: VersionSpan[]
+  boundaries: VersionBoundary[]
+}
+
+export type FlickDirection = 'left' | 'right' | 'up' | 'down'
+
+export interface Flick {
+  kill: number // 1-based
+  start: number // seconds from run start
+  end: number
+  distance: number // in the analysis unit
+  angleDeg: number // 0 = right, 90 = up
+  direction: FlickDirection
+  // False for flicks too short to analyse
+  measured: boolean
+  reactionSec: number
+  peakSpeed: number
+  overshoot: number // fraction of distance
+  undershoot: number
+  corrections: number
+  settleSec: number
+}
+
+export interface FlickGroup {
+  direction: FlickDirection
+  band: 'short' | 'long'
+  flicks: number
+  meanDistance: number
+  meanReactionSec: number
+  meanPeakSpeed: number
+  meanOvershoot: number
+  meanUndershoot: number
+  meanCorrections: number
+  meanSettleSec: number
+}
+
+export interface FlickAnalysis {
+  runId: string
+  unit: TraceUnit
+  flicks: Flick[]
+  groups: FlickGroup[]
+}
@@ -2,34 +2,110 @@
 // This file is automatically generated. DO NOT EDIT
 import {models} from '../models';
 
+export function AddGoal(arg1:models.Goal):Promise<models.Goal>;
+
+export function AddRoutine(arg1:models.Routine):Promise<models.Routine>;
+
+export function ArchiveOldStats(arg1:number,arg2:boolean):Promise<models.ArchiveResult>;
+
 export function CheckForUpdates():Promise<models.UpdateInfo>;
 
+export function CompareRuns(arg1:string,arg2:string):Promise<models.RunComparison>;
+
+export function DeleteGoal(arg1:string):Promise<boolean|string>;
+
+export function DeleteRoutine(arg1:string):Promise<boolean|string>;
+
 export function DownloadAndInstallUpdate(arg1:string):Promise<boolean|string>;
 
+export function GetActivityHeatmap(arg1:number):Promise<Array<models.DayActivity>>;
+
+export function GetArchivedStats():Promise<Array<models.ArchiveEntry>>;
+
 export function GetBenchmarkProgress(arg1:number):Promise<string>;
 
 export function GetBenchmarks():Promise<Array<models.Benchmark>>;
 
+export function GetConvertedTrace(arg1:string,arg2:string):Promise<Array<models.TracePoint>>;
+
 export function GetDefaultSettings():Promise<models.Settings>;
 
+export function GetFatigueProfiles():Promise<Array<models.FatigueProfile>>;
+
 export function GetFavoriteBenchmarks():Promise<Array<string>>;
 
+export function GetFlickAnalysis(arg1:string,arg2:string):Promise<models.FlickAnalysis>;
+
+export function GetGoalStatus(arg1:string):Promise<models.GoalStatus>;
+
+export function GetGoals():Promise<Array<models.GoalStatus>>;
+
+export function GetHighscorePrediction(arg1:string):Promise<models.HighscorePrediction>;
+
+export function GetPersonalBestHistory(arg1:string):Promise<Array<models.PersonalBest>>;
+
+export function GetPersonalBests():Promise<Array<models.PersonalBest>>;
+
+export function GetPracticeVolume():Promise<models.PracticeVolume>;
+
 export function GetRecentScenarios(arg1:number):Promise<Array<models.ScenarioRecord>>;
 
+export function GetRoutineStatus(arg1:string):Promise<models.RoutineStatus>;
+
+export function GetRoutines():Promise<Array<models.RoutineStatus>>;
+
+export function GetRunAnomalies():Promise<Array<models.RunAnomaly>>;
+
+export function GetScenarioLineage(arg1:string):Promise<models.ScenarioLineage>;
+
+export function GetScenarioSummary(arg1:string):Promise<models.ScenarioSummary>;
+
+export function GetSensitivityAnalysis():Promise<Array<models.SensitivityAnalysis>>;
+
+export function GetSensitivityAnalysisFor(arg1:string):Promise<models.SensitivityAnalysis>;
+
+export function GetSessionLengthRecommendations():Promise<Array<models.SessionLengthRecommendation>>;
+
+export function GetSessions(arg1:number):Promise<Array<models.Session>>;
+
 export function GetSettings():Promise<models.Settings>;
 
+export function GetSetupImpact():Promise<models.SetupAnalysis>;
+
+export function GetSkillRatings():Promise<Array<models.CategoryRating>>;
+
+export function GetSmoothnessTrend(arg1:string):Promise<models.SmoothnessTrend>;
+
+export function GetTimeOfDayAnalysis():Promise<models.TimeOfDayAnalysis>;
+
+export function GetTrace(arg1:string,arg2:number,arg3:number,arg4:number):Promise<models.TraceSlice>;
+
+export function GetTraceKinematics(arg1:string,arg2:string):Promise<models.TraceKinematics>;
+
+export function GetTrendFindings():Promise<Array<models.TrendFinding>>;
+
 export function GetVersion():Promise<string>;
 
 export function Greet(arg1:string):Promise<string>;
 
 export function LaunchKovaaksScenario(arg1:string,arg2:string):Promise<boolean|string>;
 
+export function PlayNextRoutineScenario(arg1:string):Promise<boolean|string>;
+
 export function ResetSettings():Promise<boolean|string>;
 
+export function RestoreArchivedStats(arg1:Array<string>,arg2:boolean):Promise<models.ArchiveResult>;
+
 export function SetFavoriteBenchmarks(arg1:Array<string>):Promise<boolean|string>;
 
+export function SetHiddenScenarios(arg1:Array<string>):Promise<boolean|string>;
+
 export function StartWatcher(arg1:string):Promise<boolean|string>;
 
 export function StopWatcher():Promise<boolean|string>;
 
+export function UpdateGoal(arg1:models.Goal):Promise<boolean|string>;
+
+export function UpdateRoutine(arg1:models.Routine):Promise<boolean|string>;
+
 export function UpdateSettings(arg1:models.Settings):Promise<boolean|string>;
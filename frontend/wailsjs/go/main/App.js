@@ -2,14 +2,46 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddGoal(arg1) {
+  return window['go']['main']['App']['AddGoal'](arg1);
+}
+
+export function AddRoutine(arg1) {
+  return window['go']['main']['App']['AddRoutine'](arg1);
+}
+
+export function ArchiveOldStats(arg1, arg2) {
+  return window['go']['main']['App']['ArchiveOldStats'](arg1, arg2);
+}
+
 export function CheckForUpdates() {
   return window['go']['main']['App']['CheckForUpdates']();
 }
 
+export function CompareRuns(arg1, arg2) {
+  return window['go']['main']['App']['CompareRuns'](arg1, arg2);
+}
+
+export function DeleteGoal(arg1) {
+  return window['go']['main']['App']['DeleteGoal'](arg1);
+}
+
+export function DeleteRoutine(arg1) {
+  return window['go']['main']['App']['DeleteRoutine'](arg1);
+}
+
 export function DownloadAndInstallUpdate(arg1) {
   return window['go']['main']['App']['DownloadAndInstallUpdate'](arg1);
 }
 
+export function GetActivityHeatmap(arg1) {
+  return window['go']['main']['App']['GetActivityHeatmap'](arg1);
+}
+
+export function GetArchivedStats() {
+  return window['go']['main']['App']['GetArchivedStats']();
+}
+
 export function GetBenchmarkProgress(arg1) {
   return window['go']['main']['App']['GetBenchmarkProgress'](arg1);
 }
@@ -18,22 +50,122 @@ export function GetBenchmarks() {
   return window['go']['main']['App']['GetBenchmarks']();
 }
 
+export function GetConvertedTrace(arg1, arg2) {
+  return window['go']['main']['App']['GetConvertedTrace'](arg1, arg2);
+}
+
 export function GetDefaultSettings() {
   return window['go']['main']['App']['GetDefaultSettings']();
 }
 
+export function GetFatigueProfiles() {
+  return window['go']['main']['App']['GetFatigueProfiles']();
+}
+
 export function GetFavoriteBenchmarks() {
   return window['go']['main']['App']['GetFavoriteBenchmarks']();
 }
 
+export function GetFlickAnalysis(arg1, arg2) {
+  return window['go']['main']['App']['GetFlickAnalysis'](arg1, arg2);
+}
+
+export function GetGoalStatus(arg1) {
+  return window['go']['main']['App']['GetGoalStatus'](arg1);
+}
+
+export function GetGoals() {
+  return window['go']['main']['App']['GetGoals']();
+}
+
+export function GetHighscorePrediction(arg1) {
+  return window['go']['main']['App']['GetHighscorePrediction'](arg1);
+}
+
+export function GetPersonalBestHistory(arg1) {
+  return window['go']['main']['App']['GetPersonalBestHistory'](arg1);
+}
+
+export function GetPersonalBests() {
+  return window['go']['main']['App']['GetPersonalBests']();
+}
+
+export function GetPracticeVolume() {
+  return window['go']['main']['App']['GetPracticeVolume']();
+}
+
 export function GetRecentScenarios(arg1) {
   return window['go']['main']['App']['GetRecentScenarios'](arg1);
 }
 
+export function GetRoutineStatus(arg1) {
+  return window['go']['main']['App']['GetRoutineStatus'](arg1);
+}
+
+export function GetRoutines() {
+  return window['go']['main']['App']['GetRoutines']();
+}
+
+export function GetRunAnomalies() {
+  return window['go']['main']['App']['GetRunAnomalies']();
+}
+
+export function GetScenarioLineage(arg1) {
+  return window['go']['main']['App']['GetScenarioLineage'](arg1);
+}
+
+export function GetScenarioSummary(arg1) {
+  return window['go']['main']['App']['GetScenarioSummary'](arg1);
+}
+
+export function GetSensitivityAnalysis() {
+  return window['go']['main']['App']['GetSensitivityAnalysis']();
+}
+
+export function GetSensitivityAnalysisFor(arg1) {
+  return window['go']['main']['App']['GetSensitivityAnalysisFor'](arg1);
+}
+
+export function GetSessionLengthRecommendations() {
+  return window['go']['main']['App']['GetSessionLengthRecommendations']();
+}
+
+export function GetSessions(arg1) {
+  return window['go']['main']['App']['GetSessions'](arg1);
+}
+
 export function GetSettings() {
   return window['go']['main']['App']['GetSettings']();
 }
 
+export function GetSetupImpact() {
+  return window['go']['main']['App']['GetSetupImpact']();
+}
+
+export function GetSkillRatings() {
+  return window['go']['main']['App']['GetSkillRatings']();
+}
+
+export function GetSmoothnessTrend(arg1) {
+  return window['go']['main']['App']['GetSmoothnessTrend'](arg1);
+}
+
+export function GetTimeOfDayAnalysis() {
+  return window['go']['main']['App']['GetTimeOfDayAnalysis']();
+}
+
+export function GetTrace(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GetTrace'](arg1, arg2, arg3, arg4);
+}
+
+export function GetTraceKinematics(arg1, arg2) {
+  return window['go']['main']['App']['GetTraceKinematics'](arg1, arg2);
+}
+
+export function GetTrendFindings() {
+  return window['go']['main']['App']['GetTrendFindings']();
+}
+
 export function GetVersion() {
   return window['go']['main']['App']['GetVersion']();
 }
@@ -46,14 +178,26 @@ export function LaunchKovaaksScenario(arg1, arg2) {
   return window['go']['main']['App']['LaunchKovaaksScenario'](arg1, arg2);
 }
 
+export function PlayNextRoutineScenario(arg1) {
+  return window['go']['main']['App']['PlayNextRoutineScenario'](arg1);
+}
+
 export function ResetSettings() {
   return window['go']['main']['App']['ResetSettings']();
 }
 
+export function RestoreArchivedStats(arg1, arg2) {
+  return window['go']['main']['App']['RestoreArchivedStats'](arg1, arg2);
+}
+
 export function SetFavoriteBenchmarks(arg1) {
   return window['go']['main']['App']['SetFavoriteBenchmarks'](arg1);
 }
 
+export function SetHiddenScenarios(arg1) {
+  return window['go']['main']['App']['SetHiddenScenarios'](arg1);
+}
+
 export function StartWatcher(arg1) {
   return window['go']['main']['App']['StartWatcher'](arg1);
 }
@@ -62,6 +206,14 @@ export function StopWatcher() {
   return window['go']['main']['App']['StopWatcher']();
 }
 
+export function UpdateGoal(arg1) {
+  return window['go']['main']['App']['UpdateGoal'](arg1);
+}
+
+export function UpdateRoutine(arg1) {
+  return window['go']['main']['App']['UpdateRoutine'](arg1);
+}
+
 export function UpdateSettings(arg1) {
   return window['go']['main']['App']['UpdateSettings'](arg1);
 }
@@ -1,5 +1,67 @@
 export namespace models {
 	
+	export class AnomalyReason {
+	    code: string;
+	    stat: string;
+	    value: number;
+	    norm: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AnomalyReason(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.stat = source["stat"];
+	        this.value = source["value"];
+	        this.norm = source["norm"];
+	    }
+	}
+	export class ArchiveEntry {
+	    fileName: string;
+	    scenarioName: string;
+	    datePlayed: string;
+	    month: string;
+	    archive: string;
+	    size: number;
+	    archivedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ArchiveEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.fileName = source["fileName"];
+	        this.scenarioName = source["scenarioName"];
+	        this.datePlayed = source["datePlayed"];
+	        this.month = source["month"];
+	        this.archive = source["archive"];
+	        this.size = source["size"];
+	        this.archivedAt = source["archivedAt"];
+	    }
+	}
+	export class ArchiveResult {
+	    dryRun: boolean;
+	    files: number;
+	    bytes: number;
+	    months: string[];
+	    errors?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ArchiveResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dryRun = source["dryRun"];
+	        this.files = source["files"];
+	        this.bytes = source["bytes"];
+	        this.months = source["months"];
+	        this.errors = source["errors"];
+	    }
+	}
 	export class BenchmarkDifficulty {
 	    difficultyName: string;
 	    kovaaksBenchmarkId: number;
@@ -61,21 +123,162 @@ export namespace models {
 		}
 	}
 	
-	export class MousePoint {
-	    // Go type: time
-	    ts: any;
+	export class RatingPoint {
+	    date: string;
+	    rating: number;
+	    rankProgress: number;
+	    runs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RatingPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.rating = source["rating"];
+	        this.rankProgress = source["rankProgress"];
+	        this.runs = source["runs"];
+	    }
+	}
+	export class CategoryRating {
+	    category: string;
+	    rating: number;
+	    rankProgress: number;
+	    runs: number;
+	    rankedRuns: number;
+	    scenarios: number;
+	    history: RatingPoint[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CategoryRating(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.category = source["category"];
+	        this.rating = source["rating"];
+	        this.rankProgress = source["rankProgress"];
+	        this.runs = source["runs"];
+	        this.rankedRuns = source["rankedRuns"];
+	        this.scenarios = source["scenarios"];
+	        this.history = this.convertValues(source["history"], RatingPoint);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CurvePoint {
 	    x: number;
-	    y: number;
+	    mean: number;
+	    count: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new MousePoint(source);
+	        return new CurvePoint(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.ts = this.convertValues(source["ts"], null);
 	        this.x = source["x"];
-	        this.y = source["y"];
+	        this.mean = source["mean"];
+	        this.count = source["count"];
+	    }
+	}
+	export class DayActivity {
+	    date: string;
+	    seconds: number;
+	    runs: number;
+	    scenarios: number;
+	    level: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DayActivity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.seconds = source["seconds"];
+	        this.runs = source["runs"];
+	        this.scenarios = source["scenarios"];
+	        this.level = source["level"];
+	    }
+	}
+	export class Distribution {
+	    count: number;
+	    min: number;
+	    max: number;
+	    mean: number;
+	    median: number;
+	    p90: number;
+	    stdDev: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Distribution(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.count = source["count"];
+	        this.min = source["min"];
+	        this.max = source["max"];
+	        this.mean = source["mean"];
+	        this.median = source["median"];
+	        this.p90 = source["p90"];
+	        this.stdDev = source["stdDev"];
+	    }
+	}
+	export class FatigueProfile {
+	    scenario: string;
+	    sessions: number;
+	    runs: number;
+	    baseline: number;
+	    sufficient: boolean;
+	    reason?: string;
+	    byPosition: CurvePoint[];
+	    byElapsed: CurvePoint[];
+	    warmupRuns: number;
+	    warmupMinutes: number;
+	    warmedLevel: number;
+	    fatigueOnsetRuns: number;
+	    fatigueOnsetMinutes: number;
+	    fatigueDecline: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FatigueProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scenario = source["scenario"];
+	        this.sessions = source["sessions"];
+	        this.runs = source["runs"];
+	        this.baseline = source["baseline"];
+	        this.sufficient = source["sufficient"];
+	        this.reason = source["reason"];
+	        this.byPosition = this.convertValues(source["byPosition"], CurvePoint);
+	        this.byElapsed = this.convertValues(source["byElapsed"], CurvePoint);
+	        this.warmupRuns = source["warmupRuns"];
+	        this.warmupMinutes = source["warmupMinutes"];
+	        this.warmedLevel = source["warmedLevel"];
+	        this.fatigueOnsetRuns = source["fatigueOnsetRuns"];
+	        this.fatigueOnsetMinutes = source["fatigueOnsetMinutes"];
+	        this.fatigueDecline = source["fatigueDecline"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -96,24 +299,88 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class ScenarioRecord {
-	    filePath: string;
-	    fileName: string;
-	    stats: Record<string, any>;
-	    events: string[][];
-	    mouseTrace?: MousePoint[];
+	export class Flick {
+	    kill: number;
+	    start: number;
+	    end: number;
+	    distance: number;
+	    angleDeg: number;
+	    direction: string;
+	    measured: boolean;
+	    reactionSec: number;
+	    peakSpeed: number;
+	    overshoot: number;
+	    undershoot: number;
+	    corrections: number;
+	    settleSec: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new ScenarioRecord(source);
+	        return new Flick(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.filePath = source["filePath"];
-	        this.fileName = source["fileName"];
-	        this.stats = source["stats"];
-	        this.events = source["events"];
-	        this.mouseTrace = this.convertValues(source["mouseTrace"], MousePoint);
+	        this.kill = source["kill"];
+	        this.start = source["start"];
+	        this.end = source["end"];
+	        this.distance = source["distance"];
+	        this.angleDeg = source["angleDeg"];
+	        this.direction = source["direction"];
+	        this.measured = source["measured"];
+	        this.reactionSec = source["reactionSec"];
+	        this.peakSpeed = source["peakSpeed"];
+	        this.overshoot = source["overshoot"];
+	        this.undershoot = source["undershoot"];
+	        this.corrections = source["corrections"];
+	        this.settleSec = source["settleSec"];
+	    }
+	}
+	export class FlickGroup {
+	    direction: string;
+	    band: string;
+	    flicks: number;
+	    meanDistance: number;
+	    meanReactionSec: number;
+	    meanPeakSpeed: number;
+	    meanOvershoot: number;
+	    meanUndershoot: number;
+	    meanCorrections: number;
+	    meanSettleSec: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FlickGroup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.direction = source["direction"];
+	        this.band = source["band"];
+	        this.flicks = source["flicks"];
+	        this.meanDistance = source["meanDistance"];
+	        this.meanReactionSec = source["meanReactionSec"];
+	        this.meanPeakSpeed = source["meanPeakSpeed"];
+	        this.meanOvershoot = source["meanOvershoot"];
+	        this.meanUndershoot = source["meanUndershoot"];
+	        this.meanCorrections = source["meanCorrections"];
+	        this.meanSettleSec = source["meanSettleSec"];
+	    }
+	}
+	export class FlickAnalysis {
+	    runId: string;
+	    unit: string;
+	    flicks: Flick[];
+	    groups: FlickGroup[];
+	
+	    static createFrom(source: any = {}) {
+	        return new FlickAnalysis(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.runId = source["runId"];
+	        this.unit = source["unit"];
+	        this.flicks = this.convertValues(source["flicks"], Flick);
+	        this.groups = this.convertValues(source["groups"], FlickGroup);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -134,56 +401,1580 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class Settings {
-	    steamInstallDir: string;
-	    steamIdOverride?: string;
-	    statsDir: string;
-	    tracesDir: string;
-	    sessionGapMinutes: number;
-	    theme: string;
-	    favoriteBenchmarks?: string[];
-	    mouseTrackingEnabled: boolean;
-	    mouseBufferMinutes: number;
-	    maxExistingOnStart: number;
+	
+	export class Goal {
+	    id: string;
+	    scenario: string;
+	    metric: string;
+	    target: number;
+	    deadline?: string;
+	    createdAt: string;
+	    achievedAt?: string;
+	    achievedRunId?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new Settings(source);
+	        return new Goal(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.steamInstallDir = source["steamInstallDir"];
-	        this.steamIdOverride = source["steamIdOverride"];
-	        this.statsDir = source["statsDir"];
-	        this.tracesDir = source["tracesDir"];
-	        this.sessionGapMinutes = source["sessionGapMinutes"];
-	        this.theme = source["theme"];
-	        this.favoriteBenchmarks = source["favoriteBenchmarks"];
-	        this.mouseTrackingEnabled = source["mouseTrackingEnabled"];
-	        this.mouseBufferMinutes = source["mouseBufferMinutes"];
-	        this.maxExistingOnStart = source["maxExistingOnStart"];
+	        this.id = source["id"];
+	        this.scenario = source["scenario"];
+	        this.metric = source["metric"];
+	        this.target = source["target"];
+	        this.deadline = source["deadline"];
+	        this.createdAt = source["createdAt"];
+	        this.achievedAt = source["achievedAt"];
+	        this.achievedRunId = source["achievedRunId"];
 	    }
 	}
-	export class UpdateInfo {
-	    currentVersion: string;
-	    latestVersion: string;
-	    hasUpdate: boolean;
-	    downloadUrl?: string;
-	    releaseNotes?: string;
+	export class GoalStatus {
+	    goal: Goal;
+	    best: number;
+	    progress: number;
+	    runs: number;
+	    achieved: boolean;
+	    eta?: string;
+	    onTrack: boolean;
+	    overdue: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new UpdateInfo(source);
+	        return new GoalStatus(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.currentVersion = source["currentVersion"];
-	        this.latestVersion = source["latestVersion"];
-	        this.hasUpdate = source["hasUpdate"];
-	        this.downloadUrl = source["downloadUrl"];
-	        this.releaseNotes = source["releaseNotes"];
+	        this.goal = this.convertValues(source["goal"], Goal);
+	        this.best = source["best"];
+	        this.progress = source["progress"];
+	        this.runs = source["runs"];
+	        this.achieved = source["achieved"];
+	        this.eta = source["eta"];
+	        this.onTrack = source["onTrack"];
+	        this.overdue = source["overdue"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HighscorePrediction {
+	    scenario: string;
+	    predictedScore: number;
+	    runsExpected: number;
+	    runsLo: number;
+	    runsHi: number;
+	    eta?: string;
+	    optPauseHours: number;
+	    confidence: string;
+	    confidenceScore: number;
+	    sample: number;
+	    best: number;
+	    lastScore: number;
+	    lastPlayedDays: number;
+	    slopePerDay: number;
+	    slopePerRun: number;
+	    reason?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HighscorePrediction(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scenario = source["scenario"];
+	        this.predictedScore = source["predictedScore"];
+	        this.runsExpected = source["runsExpected"];
+	        this.runsLo = source["runsLo"];
+	        this.runsHi = source["runsHi"];
+	        this.eta = source["eta"];
+	        this.optPauseHours = source["optPauseHours"];
+	        this.confidence = source["confidence"];
+	        this.confidenceScore = source["confidenceScore"];
+	        this.sample = source["sample"];
+	        this.best = source["best"];
+	        this.lastScore = source["lastScore"];
+	        this.lastPlayedDays = source["lastPlayedDays"];
+	        this.slopePerDay = source["slopePerDay"];
+	        this.slopePerRun = source["slopePerRun"];
+	        this.reason = source["reason"];
+	    }
+	}
+	export class KillDelta {
+	    index: number;
+	    a: number;
+	    b: number;
+	    delta: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new KillDelta(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.index = source["index"];
+	        this.a = source["a"];
+	        this.b = source["b"];
+	        this.delta = source["delta"];
+	    }
+	}
+	export class KinematicSample {
+	    t: number;
+	    speed: number;
+	    accel: number;
+	    jerk: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new KinematicSample(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.t = source["t"];
+	        this.speed = source["speed"];
+	        this.accel = source["accel"];
+	        this.jerk = source["jerk"];
+	    }
+	}
+	export class LengthStats {
+	    runs: number;
+	    mean: number;
+	    min: number;
+	    max: number;
+	    std: number;
+	    p10: number;
+	    p90: number;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LengthStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.runs = source["runs"];
+	        this.mean = source["mean"];
+	        this.min = source["min"];
+	        this.max = source["max"];
+	        this.std = source["std"];
+	        this.p10 = source["p10"];
+	        this.p90 = source["p90"];
+	        this.count = source["count"];
+	    }
+	}
+	export class MousePoint {
+	    // Go type: time
+	    ts: any;
+	    x: number;
+	    y: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MousePoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ts = this.convertValues(source["ts"], null);
+	        this.x = source["x"];
+	        this.y = source["y"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PersonalBest {
+	    scenario: string;
+	    runId: string;
+	    datePlayed: string;
+	    score: number;
+	    previousScore: number;
+	    margin: number;
+	    marginPct: number;
+	    first?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PersonalBest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scenario = source["scenario"];
+	        this.runId = source["runId"];
+	        this.datePlayed = source["datePlayed"];
+	        this.score = source["score"];
+	        this.previousScore = source["previousScore"];
+	        this.margin = source["margin"];
+	        this.marginPct = source["marginPct"];
+	        this.first = source["first"];
+	    }
+	}
+	export class WeekActivity {
+	    week: string;
+	    seconds: number;
+	    runs: number;
+	    scenarios: number;
+	    days: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WeekActivity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.week = source["week"];
+	        this.seconds = source["seconds"];
+	        this.runs = source["runs"];
+	        this.scenarios = source["scenarios"];
+	        this.days = source["days"];
 	    }
 	}
+	export class PracticeVolume {
+	    totalSeconds: number;
+	    totalRuns: number;
+	    scenarios: number;
+	    daysPractised: number;
+	    currentStreak: number;
+	    longestStreak: number;
+	    days: DayActivity[];
+	    weeks: WeekActivity[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PracticeVolume(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.totalSeconds = source["totalSeconds"];
+	        this.totalRuns = source["totalRuns"];
+	        this.scenarios = source["scenarios"];
+	        this.daysPractised = source["daysPractised"];
+	        this.currentStreak = source["currentStreak"];
+	        this.longestStreak = source["longestStreak"];
+	        this.days = this.convertValues(source["days"], DayActivity);
+	        this.weeks = this.convertValues(source["weeks"], WeekActivity);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class RoutineItem {
+	    scenario: string;
+	    runs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoutineItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scenario = source["scenario"];
+	        this.runs = source["runs"];
+	    }
+	}
+	export class Routine {
+	    id: string;
+	    name: string;
+	    items: RoutineItem[];
+	    days: number[];
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Routine(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.items = this.convertValues(source["items"], RoutineItem);
+	        this.days = source["days"];
+	        this.createdAt = source["createdAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class RoutineItemProgress {
+	    scenario: string;
+	    target: number;
+	    done: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoutineItemProgress(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scenario = source["scenario"];
+	        this.target = source["target"];
+	        this.done = source["done"];
+	    }
+	}
+	export class RoutineStatus {
+	    routine: Routine;
+	    scheduledToday: boolean;
+	    items: RoutineItemProgress[];
+	    progress: number;
+	    completed: boolean;
+	    nextScenario?: string;
+	    streak: number;
+	    bestStreak: number;
+	    adherence: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoutineStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.routine = this.convertValues(source["routine"], Routine);
+	        this.scheduledToday = source["scheduledToday"];
+	        this.items = this.convertValues(source["items"], RoutineItemProgress);
+	        this.progress = source["progress"];
+	        this.completed = source["completed"];
+	        this.nextScenario = source["nextScenario"];
+	        this.streak = source["streak"];
+	        this.bestStreak = source["bestStreak"];
+	        this.adherence = source["adherence"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RunAnomaly {
+	    id: string;
+	    scenario: string;
+	    played: string;
+	    score: number;
+	    reasons: AnomalyReason[];
+	    excluded: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RunAnomaly(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.scenario = source["scenario"];
+	        this.played = source["played"];
+	        this.score = source["score"];
+	        this.reasons = this.convertValues(source["reasons"], AnomalyReason);
+	        this.excluded = source["excluded"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TraceMetrics {
+	    points: number;
+	    durationSec: number;
+	    pathLength: number;
+	    meanSpeed: number;
+	    peakSpeed: number;
+	    directionChanges: number;
+	    displacement: number;
+	    pathEfficiency: number;
+	    movements: number;
+	    meanAccel: number;
+	    peakAccel: number;
+	    meanJerk: number;
+	    peakJerk: number;
+	    movingSec: number;
+	    stationarySec: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TraceMetrics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.points = source["points"];
+	        this.durationSec = source["durationSec"];
+	        this.pathLength = source["pathLength"];
+	        this.meanSpeed = source["meanSpeed"];
+	        this.peakSpeed = source["peakSpeed"];
+	        this.directionChanges = source["directionChanges"];
+	        this.displacement = source["displacement"];
+	        this.pathEfficiency = source["pathEfficiency"];
+	        this.movements = source["movements"];
+	        this.meanAccel = source["meanAccel"];
+	        this.peakAccel = source["peakAccel"];
+	        this.meanJerk = source["meanJerk"];
+	        this.peakJerk = source["peakJerk"];
+	        this.movingSec = source["movingSec"];
+	        this.stationarySec = source["stationarySec"];
+	    }
+	}
+	export class TraceComparison {
+	    unit: string;
+	    a: TraceMetrics;
+	    b: TraceMetrics;
+	    diffs: StatDiff[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TraceComparison(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.unit = source["unit"];
+	        this.a = this.convertValues(source["a"], TraceMetrics);
+	        this.b = this.convertValues(source["b"], TraceMetrics);
+	        this.diffs = this.convertValues(source["diffs"], StatDiff);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SetupChange {
+	    key: string;
+	    before: string;
+	    after: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SetupChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.before = source["before"];
+	        this.after = source["after"];
+	    }
+	}
+	export class StatDiff {
+	    key: string;
+	    a: number;
+	    b: number;
+	    delta: number;
+	    rel: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.a = source["a"];
+	        this.b = source["b"];
+	        this.delta = source["delta"];
+	        this.rel = source["rel"];
+	    }
+	}
+	export class RunRef {
+	    id: string;
+	    scenario: string;
+	    played: string;
+	    score: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RunRef(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.scenario = source["scenario"];
+	        this.played = source["played"];
+	        this.score = source["score"];
+	    }
+	}
+	export class RunComparison {
+	    a: RunRef;
+	    b: RunRef;
+	    stats: StatDiff[];
+	    kills: KillDelta[];
+	    setup: SetupChange[];
+	    trace?: TraceComparison;
+	
+	    static createFrom(source: any = {}) {
+	        return new RunComparison(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.a = this.convertValues(source["a"], RunRef);
+	        this.b = this.convertValues(source["b"], RunRef);
+	        this.stats = this.convertValues(source["stats"], StatDiff);
+	        this.kills = this.convertValues(source["kills"], KillDelta);
+	        this.setup = this.convertValues(source["setup"], SetupChange);
+	        this.trace = this.convertValues(source["trace"], TraceComparison);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ScenarioFilter {
+	    action: string;
+	    pattern: string;
+	    regex?: boolean;
+	    mode?: string;
+	    minDurationSeconds?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScenarioFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.action = source["action"];
+	        this.pattern = source["pattern"];
+	        this.regex = source["regex"];
+	        this.mode = source["mode"];
+	        this.minDurationSeconds = source["minDurationSeconds"];
+	    }
+	}
+	export class VersionBoundary {
+	    scenario: string;
+	    kind: string;
+	    runId: string;
+	    at: string;
+	    before: string;
+	    after: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new VersionBoundary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scenario = source["scenario"];
+	        this.kind = source["kind"];
+	        this.runId = source["runId"];
+	        this.at = source["at"];
+	        this.before = source["before"];
+	        this.after = source["after"];
+	    }
+	}
+	export class VersionSpan {
+	    value: string;
+	    first: string;
+	    last: string;
+	    runs: number;
+	    bestScore: number;
+	    current: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new VersionSpan(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.value = source["value"];
+	        this.first = source["first"];
+	        this.last = source["last"];
+	        this.runs = source["runs"];
+	        this.bestScore = source["bestScore"];
+	        this.current = source["current"];
+	    }
+	}
+	export class ScenarioLineage {
+	    scenario: string;
+	    hashes: VersionSpan[];
+	    gameVersions: VersionSpan[];
+	    boundaries: VersionBoundary[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ScenarioLineage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scenario = source["scenario"];
+	        this.hashes = this.convertValues(source["hashes"], VersionSpan);
+	        this.gameVersions = this.convertValues(source["gameVersions"], VersionSpan);
+	        this.boundaries = this.convertValues(source["boundaries"], VersionBoundary);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ScenarioRecord {
+	    filePath: string;
+	    fileName: string;
+	    stats: Record<string, any>;
+	    events: string[][];
+	    tracePoints?: number;
+	    hidden?: boolean;
+	    anomalies?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ScenarioRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filePath = source["filePath"];
+	        this.fileName = source["fileName"];
+	        this.stats = source["stats"];
+	        this.events = source["events"];
+	        this.tracePoints = source["tracePoints"];
+	        this.hidden = source["hidden"];
+	        this.anomalies = source["anomalies"];
+	    }
+	}
+	export class ScenarioSummary {
+	    scenario: string;
+	    count: number;
+	    playSeconds: number;
+	    bestScore: number;
+	    meanScore: number;
+	    medianScore: number;
+	    p90Score: number;
+	    accuracy: Distribution;
+	    ttk: Distribution;
+	    trendSlope: number;
+	    trendRuns: number;
+	    firstPlayed: string;
+	    lastPlayed: string;
+	    daysSinceLastPlayed: number;
+	    versionBoundaries?: VersionBoundary[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ScenarioSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scenario = source["scenario"];
+	        this.count = source["count"];
+	        this.playSeconds = source["playSeconds"];
+	        this.bestScore = source["bestScore"];
+	        this.meanScore = source["meanScore"];
+	        this.medianScore = source["medianScore"];
+	        this.p90Score = source["p90Score"];
+	        this.accuracy = this.convertValues(source["accuracy"], Distribution);
+	        this.ttk = this.convertValues(source["ttk"], Distribution);
+	        this.trendSlope = source["trendSlope"];
+	        this.trendRuns = source["trendRuns"];
+	        this.firstPlayed = source["firstPlayed"];
+	        this.lastPlayed = source["lastPlayed"];
+	        this.daysSinceLastPlayed = source["daysSinceLastPlayed"];
+	        this.versionBoundaries = this.convertValues(source["versionBoundaries"], VersionBoundary);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SensBin {
+	    lo: number;
+	    hi: number;
+	    runs: number;
+	    mean: number;
+	    stdErr: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SensBin(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.lo = source["lo"];
+	        this.hi = source["hi"];
+	        this.runs = source["runs"];
+	        this.mean = source["mean"];
+	        this.stdErr = source["stdErr"];
+	    }
+	}
+	export class SensitivityAnalysis {
+	    group: string;
+	    kind: string;
+	    runs: number;
+	    bins: SensBin[];
+	    sufficient: boolean;
+	    reason?: string;
+	    bestLo: number;
+	    bestHi: number;
+	    gain: number;
+	    confidence: string;
+	    tStat: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SensitivityAnalysis(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.group = source["group"];
+	        this.kind = source["kind"];
+	        this.runs = source["runs"];
+	        this.bins = this.convertValues(source["bins"], SensBin);
+	        this.sufficient = source["sufficient"];
+	        this.reason = source["reason"];
+	        this.bestLo = source["bestLo"];
+	        this.bestHi = source["bestHi"];
+	        this.gain = source["gain"];
+	        this.confidence = source["confidence"];
+	        this.tStat = source["tStat"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SessionScenario {
+	    name: string;
+	    runs: number;
+	    bestScore: number;
+	    avgScore: number;
+	    avgAccuracy: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionScenario(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.runs = source["runs"];
+	        this.bestScore = source["bestScore"];
+	        this.avgScore = source["avgScore"];
+	        this.avgAccuracy = source["avgAccuracy"];
+	    }
+	}
+	export class Session {
+	    id: string;
+	    start: string;
+	    end: string;
+	    active: boolean;
+	    runs: number;
+	    runIds: string[];
+	    scenarios: SessionScenario[];
+	    playSeconds: number;
+	    avgAccuracy: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Session(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.start = source["start"];
+	        this.end = source["end"];
+	        this.active = source["active"];
+	        this.runs = source["runs"];
+	        this.runIds = source["runIds"];
+	        this.scenarios = this.convertValues(source["scenarios"], SessionScenario);
+	        this.playSeconds = source["playSeconds"];
+	        this.avgAccuracy = source["avgAccuracy"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SessionLengthRecommendation {
+	    scenario: string;
+	    sessions: number;
+	    sufficient: boolean;
+	    reason?: string;
+	    warmupRuns: number;
+	    optimalAvgRuns: number;
+	    optimalConsistentRuns: number;
+	    optimalHighscoreRuns: number;
+	    recommendedRuns: number;
+	    recommendedLo: number;
+	    recommendedHi: number;
+	    expectedBest: number[];
+	    expectedAvg: LengthStats[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionLengthRecommendation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scenario = source["scenario"];
+	        this.sessions = source["sessions"];
+	        this.sufficient = source["sufficient"];
+	        this.reason = source["reason"];
+	        this.warmupRuns = source["warmupRuns"];
+	        this.optimalAvgRuns = source["optimalAvgRuns"];
+	        this.optimalConsistentRuns = source["optimalConsistentRuns"];
+	        this.optimalHighscoreRuns = source["optimalHighscoreRuns"];
+	        this.recommendedRuns = source["recommendedRuns"];
+	        this.recommendedLo = source["recommendedLo"];
+	        this.recommendedHi = source["recommendedHi"];
+	        this.expectedBest = source["expectedBest"];
+	        this.expectedAvg = this.convertValues(source["expectedAvg"], LengthStats);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Settings {
+	    steamInstallDir: string;
+	    steamIdOverride?: string;
+	    statsDir: string;
+	    tracesDir: string;
+	    sessionGapMinutes: number;
+	    theme: string;
+	    favoriteBenchmarks?: string[];
+	    mouseTrackingEnabled: boolean;
+	    mouseBufferMinutes: number;
+	    maxExistingOnStart: number;
+	    scenarioFilters?: ScenarioFilter[];
+	    hiddenScenarios?: string[];
+	    excludeAnomalousRuns?: boolean;
+	    currentVersionOnly?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Settings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.steamInstallDir = source["steamInstallDir"];
+	        this.steamIdOverride = source["steamIdOverride"];
+	        this.statsDir = source["statsDir"];
+	        this.tracesDir = source["tracesDir"];
+	        this.sessionGapMinutes = source["sessionGapMinutes"];
+	        this.theme = source["theme"];
+	        this.favoriteBenchmarks = source["favoriteBenchmarks"];
+	        this.mouseTrackingEnabled = source["mouseTrackingEnabled"];
+	        this.mouseBufferMinutes = source["mouseBufferMinutes"];
+	        this.maxExistingOnStart = source["maxExistingOnStart"];
+	        this.scenarioFilters = this.convertValues(source["scenarioFilters"], ScenarioFilter);
+	        this.hiddenScenarios = source["hiddenScenarios"];
+	        this.excludeAnomalousRuns = source["excludeAnomalousRuns"];
+	        this.currentVersionOnly = source["currentVersionOnly"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SetupImpact {
+	    scenario: string;
+	    runsBefore: number;
+	    runsAfter: number;
+	    meanBefore: number;
+	    meanAfter: number;
+	    expected: number;
+	    effect: number;
+	    effectSize: number;
+	    tStat: number;
+	    confidence: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SetupImpact(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scenario = source["scenario"];
+	        this.runsBefore = source["runsBefore"];
+	        this.runsAfter = source["runsAfter"];
+	        this.meanBefore = source["meanBefore"];
+	        this.meanAfter = source["meanAfter"];
+	        this.expected = source["expected"];
+	        this.effect = source["effect"];
+	        this.effectSize = source["effectSize"];
+	        this.tStat = source["tStat"];
+	        this.confidence = source["confidence"];
+	    }
+	}
+	export class SetupChangePoint {
+	    at: string;
+	    epoch: number;
+	    categories: string[];
+	    changes: SetupChange[];
+	    impacts: SetupImpact[];
+	    meanEffect: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SetupChangePoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.at = source["at"];
+	        this.epoch = source["epoch"];
+	        this.categories = source["categories"];
+	        this.changes = this.convertValues(source["changes"], SetupChange);
+	        this.impacts = this.convertValues(source["impacts"], SetupImpact);
+	        this.meanEffect = source["meanEffect"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SetupEpoch {
+	    index: number;
+	    start: string;
+	    end: string;
+	    runs: number;
+	    settings: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new SetupEpoch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.index = source["index"];
+	        this.start = source["start"];
+	        this.end = source["end"];
+	        this.runs = source["runs"];
+	        this.settings = source["settings"];
+	    }
+	}
+	export class SetupAnalysis {
+	    epochs: SetupEpoch[];
+	    changes: SetupChangePoint[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SetupAnalysis(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.epochs = this.convertValues(source["epochs"], SetupEpoch);
+	        this.changes = this.convertValues(source["changes"], SetupChangePoint);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	
+	export class SmoothnessPoint {
+	    runId: string;
+	    played: string;
+	    score: number;
+	    tremorRatio: number;
+	    smoothness: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SmoothnessPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.runId = source["runId"];
+	        this.played = source["played"];
+	        this.score = source["score"];
+	        this.tremorRatio = source["tremorRatio"];
+	        this.smoothness = source["smoothness"];
+	    }
+	}
+	export class SmoothnessTrend {
+	    scenario: string;
+	    points: SmoothnessPoint[];
+	    tremorSlope: number;
+	    smoothnessSlope: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SmoothnessTrend(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scenario = source["scenario"];
+	        this.points = this.convertValues(source["points"], SmoothnessPoint);
+	        this.tremorSlope = source["tremorSlope"];
+	        this.smoothnessSlope = source["smoothnessSlope"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SpectrumBin {
+	    hz: number;
+	    power: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SpectrumBin(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hz = source["hz"];
+	        this.power = source["power"];
+	    }
+	}
+	
+	export class TimeBucket {
+	    key: number;
+	    runs: number;
+	    meanPercentile: number;
+	    scoreDelta: number;
+	    stdErr: number;
+	    sufficient: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimeBucket(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.runs = source["runs"];
+	        this.meanPercentile = source["meanPercentile"];
+	        this.scoreDelta = source["scoreDelta"];
+	        this.stdErr = source["stdErr"];
+	        this.sufficient = source["sufficient"];
+	    }
+	}
+	export class TimeWindow {
+	    startHour: number;
+	    endHour: number;
+	    runs: number;
+	    scoreDelta: number;
+	    tStat: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimeWindow(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.startHour = source["startHour"];
+	        this.endHour = source["endHour"];
+	        this.runs = source["runs"];
+	        this.scoreDelta = source["scoreDelta"];
+	        this.tStat = source["tStat"];
+	    }
+	}
+	export class TimeOfDayAnalysis {
+	    runs: number;
+	    scenarios: number;
+	    byHour: TimeBucket[];
+	    byWeekday: TimeBucket[];
+	    bestWindow?: TimeWindow;
+	    worstWindow?: TimeWindow;
+	    sufficient: boolean;
+	    reason?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimeOfDayAnalysis(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.runs = source["runs"];
+	        this.scenarios = source["scenarios"];
+	        this.byHour = this.convertValues(source["byHour"], TimeBucket);
+	        this.byWeekday = this.convertValues(source["byWeekday"], TimeBucket);
+	        this.bestWindow = this.convertValues(source["bestWindow"], TimeWindow);
+	        this.worstWindow = this.convertValues(source["worstWindow"], TimeWindow);
+	        this.sufficient = source["sufficient"];
+	        this.reason = source["reason"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class TraceSpectrum {
+	    sampleHz: number;
+	    windows: number;
+	    bandLowHz: number;
+	    bandHighHz: number;
+	    tremorRms: number;
+	    tremorRatio: number;
+	    peakTremorHz: number;
+	    smoothness: number;
+	    psd: SpectrumBin[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TraceSpectrum(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sampleHz = source["sampleHz"];
+	        this.windows = source["windows"];
+	        this.bandLowHz = source["bandLowHz"];
+	        this.bandHighHz = source["bandHighHz"];
+	        this.tremorRms = source["tremorRms"];
+	        this.tremorRatio = source["tremorRatio"];
+	        this.peakTremorHz = source["peakTremorHz"];
+	        this.smoothness = source["smoothness"];
+	        this.psd = this.convertValues(source["psd"], SpectrumBin);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TraceKinematics {
+	    version: number;
+	    unit: string;
+	    metrics: TraceMetrics;
+	    binSec: number;
+	    profile: KinematicSample[];
+	    spectrum: TraceSpectrum;
+	
+	    static createFrom(source: any = {}) {
+	        return new TraceKinematics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.unit = source["unit"];
+	        this.metrics = this.convertValues(source["metrics"], TraceMetrics);
+	        this.binSec = source["binSec"];
+	        this.profile = this.convertValues(source["profile"], KinematicSample);
+	        this.spectrum = this.convertValues(source["spectrum"], TraceSpectrum);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TracePoint {
+	    // Go type: time
+	    ts: any;
+	    x: number;
+	    y: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TracePoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ts = this.convertValues(source["ts"], null);
+	        this.x = source["x"];
+	        this.y = source["y"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TraceSlice {
+	    runId: string;
+	    total: number;
+	    matched: number;
+	    durationSec: number;
+	    fromSec: number;
+	    toSec: number;
+	    points: MousePoint[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TraceSlice(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.runId = source["runId"];
+	        this.total = source["total"];
+	        this.matched = source["matched"];
+	        this.durationSec = source["durationSec"];
+	        this.fromSec = source["fromSec"];
+	        this.toSec = source["toSec"];
+	        this.points = this.convertValues(source["points"], MousePoint);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TrendFinding {
+	    scenario: string;
+	    kind: string;
+	    since: string;
+	    sessions: number;
+	    before: number;
+	    after: number;
+	    change: number;
+	    tStat: number;
+	    setupChanges?: SetupChange[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TrendFinding(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scenario = source["scenario"];
+	        this.kind = source["kind"];
+	        this.since = source["since"];
+	        this.sessions = source["sessions"];
+	        this.before = source["before"];
+	        this.after = source["after"];
+	        this.change = source["change"];
+	        this.tStat = source["tStat"];
+	        this.setupChanges = this.convertValues(source["setupChanges"], SetupChange);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class UpdateInfo {
+	    currentVersion: string;
+	    latestVersion: string;
+	    hasUpdate: boolean;
+	    downloadUrl?: string;
+	    releaseNotes?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.currentVersion = source["currentVersion"];
+	        this.latestVersion = source["latestVersion"];
+	        this.hasUpdate = source["hasUpdate"];
+	        this.downloadUrl = source["downloadUrl"];
+	        this.releaseNotes = source["releaseNotes"];
+	    }
+	}
+	
+	
 
 }
 
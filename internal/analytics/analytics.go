@@ -0,0 +1,190 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"refleks/internal/constants"
+	"refleks/internal/history"
+	"refleks/internal/models"
+	"refleks/internal/util"
+)
+
+// series accumulates values incrementally, keeping them sorted for percentiles.
+type series struct {
+	sum, sumSq float64
+	sorted     []float64
+}
+
+func (s *series) add(v float64) {
+	s.sum += v
+	s.sumSq += v * v
+	i := sort.SearchFloat64s(s.sorted, v)
+	s.sorted = append(s.sorted, 0)
+	copy(s.sorted[i+1:], s.sorted[i:])
+	s.sorted[i] = v
+}
+
+func (s *series) distribution() models.Distribution {
+	n := len(s.sorted)
+	if n == 0 {
+		return models.Distribution{}
+	}
+	mean := s.sum / float64(n)
+	return models.Distribution{
+		Count:  n,
+		Min:    s.sorted[0],
+		Max:    s.sorted[n-1],
+		Mean:   mean,
+		Median: Percentile(s.sorted, 0.5),
+		P90:    Percentile(s.sorted, 0.9),
+		StdDev: math.Sqrt(math.Max(0, s.sumSq/float64(n)-mean*mean)),
+	}
+}
+
+// Percentile returns the p-quantile (0..1) of sorted values using linear interpolation.
+func Percentile(sorted []float64, p float64) float64 {
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
+	pos := p * float64(n-1)
+	lo := int(math.Floor(pos))
+	if lo >= n-1 {
+		return sorted[n-1]
+	}
+	return sorted[lo] + (pos-float64(lo))*(sorted[lo+1]-sorted[lo])
+}
+
+// Slope returns the least-squares slope of ys against their index.
+func Slope(ys []float64) float64 {
+	n := float64(len(ys))
+	if n < 2 {
+		return 0
+	}
+	var sx, sy, sxy, sxx float64
+	for i, y := range ys {
+		x := float64(i)
+		sx += x
+		sy += y
+		sxy += x * y
+		sxx += x * x
+	}
+	den := n*sxx - sx*sx
+	if den == 0 {
+		return 0
+	}
+	return (n*sxy - sx*sy) / den
+}
+
+type point struct {
+	t     time.Time
+	score float64
+}
+
+// scenario holds the running aggregates of one scenario.
+type scenario struct {
+	playSeconds float64
+	best        float64
+	scores      series
+	acc         series
+	ttk         series
+	timeline    []point // oldest first
+}
+
+func (s *scenario) add(r history.Run) {
+	if d := r.End.Sub(r.Start); d > 0 {
+		s.playSeconds += d.Seconds()
+	}
+	if len(s.timeline) == 0 || r.Score > s.best {
+		s.best = r.Score
+	}
+	s.scores.add(r.Score)
+	s.acc.add(util.ToFloat(r.Stats["Accuracy"]))
+	if ttk := util.ToFloat(r.Stats["Real Avg TTK"]); ttk > 0 {
+		s.ttk.add(ttk)
+	}
+	// Runs almost always arrive in order; insert to keep the trend correct when not.
+	i := sort.Search(len(s.timeline), func(i int) bool { return s.timeline[i].t.After(r.End) })
+	s.timeline = append(s.timeline, point{})
+	copy(s.timeline[i+1:], s.timeline[i:])
+	s.timeline[i] = point{t: r.End, score: r.Score}
+}
+
+// Engine maintains per-scenario aggregates that are updated as runs arrive, so
+// a summary never requires rescanning history. It is safe for concurrent use.
+type Engine struct {
+	mu        sync.RWMutex
+	scenarios map[string]*scenario
+}
+
+// New returns an empty engine.
+func New() *Engine {
+	return &Engine{scenarios: make(map[string]*scenario)}
+}
+
+// Rebuild replaces all aggregates with ones computed from runs.
+func (e *Engine) Rebuild(runs []history.Run) {
+	next := make(map[string]*scenario)
+	for _, r := range runs {
+		s, ok := next[r.Scenario]
+		if !ok {
+			s = &scenario{}
+			next[r.Scenario] = s
+		}
+		s.add(r)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.scenarios = next
+}
+
+// Add folds a single run into its scenario's aggregates.
+func (e *Engine) Add(r history.Run) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	s, ok := e.scenarios[r.Scenario]
+	if !ok {
+		s = &scenario{}
+		e.scenarios[r.Scenario] = s
+	}
+	s.add(r)
+}
+
+// Summary returns the aggregate statistics of a scenario as of now.
+func (e *Engine) Summary(name string, now time.Time) (models.ScenarioSummary, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	s, ok := e.scenarios[name]
+	if !ok || len(s.timeline) == 0 {
+		return models.ScenarioSummary{}, false
+	}
+	scores := s.scores.distribution()
+	tail := s.timeline
+	if len(tail) > constants.DefaultTrendRuns {
+		tail = tail[len(tail)-constants.DefaultTrendRuns:]
+	}
+	ys := make([]float64, len(tail))
+	for i, p := range tail {
+		ys[i] = p.score
+	}
+	first, last := s.timeline[0].t, s.timeline[len(s.timeline)-1].t
+	return models.ScenarioSummary{
+		Scenario:            name,
+		Count:               scores.Count,
+		PlaySeconds:         s.playSeconds,
+		BestScore:           s.best,
+		MeanScore:           scores.Mean,
+		MedianScore:         scores.Median,
+		P90Score:            scores.P90,
+		Accuracy:            s.acc.distribution(),
+		TTK:                 s.ttk.distribution(),
+		TrendSlope:          Slope(ys),
+		TrendRuns:           len(ys),
+		FirstPlayed:         first.Format(time.RFC3339),
+		LastPlayed:          last.Format(time.RFC3339),
+		DaysSinceLastPlayed: math.Max(0, now.Sub(last).Hours()/24),
+	}, true
+}
@@ -0,0 +1,39 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"refleks/internal/history"
+)
+
+func TestSummaryIncremental(t *testing.T) {
+	base := time.Date(2025, 10, 2, 18, 0, 0, 0, time.Local)
+	run := func(i int, score, acc float64) history.Run {
+		end := base.Add(time.Duration(i) * time.Minute)
+		return history.Run{ID: string(rune('a' + i)), Scenario: "VT 1w3ts Intermediate S5", Start: end.Add(-time.Minute), End: end, Score: score, Stats: map[string]any{"Accuracy": acc}}
+	}
+	e := New()
+	e.Rebuild([]history.Run{run(0, 100, 0.5), run(1, 110, 0.6)})
+	e.Add(run(3, 130, 0.8))
+	// Out of order, but the trend still sees scores oldest first.
+	e.Add(run(2, 120, 0.7))
+
+	got, ok := e.Summary("VT 1w3ts Intermediate S5", base.Add(49*time.Hour))
+	if !ok {
+		t.Fatal("expected a summary")
+	}
+	if got.Count != 4 || got.BestScore != 130 || got.MeanScore != 115 || got.MedianScore != 115 {
+		t.Fatalf("unexpected score aggregates: %+v", got)
+	}
+	if got.PlaySeconds != 240 || math.Abs(got.TrendSlope-10) > 1e-9 || math.Abs(got.Accuracy.Mean-0.65) > 1e-9 {
+		t.Fatalf("unexpected playtime/trend/accuracy: %+v", got)
+	}
+	if got.TTK.Count != 0 || got.DaysSinceLastPlayed < 2 {
+		t.Fatalf("unexpected ttk/recency: %+v", got)
+	}
+	if _, ok := e.Summary("unknown", base); ok {
+		t.Fatal("unknown scenario should have no summary")
+	}
+}
@@ -0,0 +1,242 @@
+package anomaly
+
+import (
+	"math"
+
+	"refleks/internal/history"
+	"refleks/internal/models"
+	"refleks/internal/util"
+)
+
+// Reason codes attached to flagged runs.
+const (
+	ReasonFPSDrop         = "fps_drop"
+	ReasonTimeDilation    = "time_dilation"
+	ReasonInputLag        = "input_lag"
+	ReasonResolutionScale = "resolution_scale"
+	ReasonTargetScale     = "target_scale"
+)
+
+const (
+	// globalWindow is how many preceding runs of any scenario form the norm for
+	// machine-wide stats (FPS, input lag, resolution scale).
+	globalWindow = 50
+	// scenarioWindow is how many preceding runs of the same scenario form the norm
+	// for scenario-dependent stats (time dilation, target scale).
+	scenarioWindow = 20
+	// minHistory is the fewest preceding runs a norm is derived from.
+	minHistory = 5
+	// settleRuns is how many runs in a row a changed setting must hold to become the
+	// norm; a deliberate change then stops being flagged.
+	settleRuns = 3
+
+	fpsDropRatio         = 0.85
+	dilationTolerance    = 0.02
+	targetScaleTolerance = 0.05
+)
+
+// settings are machine settings judged against their usual value rather than a median.
+var settings = []struct{ code, key string }{
+	{ReasonInputLag, "Input Lag"},
+	{ReasonResolutionScale, "Resolution Scale"},
+}
+
+// Check judges r against the runs played before it, oldest first.
+func Check(prev []history.Run, r history.Run) []models.AnomalyReason {
+	global := prev[max(0, len(prev)-globalWindow):]
+	var scen []history.Run
+	for i := len(prev) - 1; i >= 0 && len(scen) < scenarioWindow; i-- {
+		if prev[i].Scenario == r.Scenario {
+			scen = append(scen, prev[i])
+		}
+	}
+	return check(global, scen, r)
+}
+
+// Detect checks every run against the runs before it and returns the reasons of
+// flagged runs by ID. Runs must be oldest first. Unlike Check it knows the later
+// runs, so the first runs of a setting change that lasted are not flagged.
+func Detect(runs []history.Run) map[string][]models.AnomalyReason {
+	out := make(map[string][]models.AnomalyReason)
+	byScenario := make(map[string][]history.Run)
+	for i, r := range runs {
+		scen := byScenario[r.Scenario]
+		if reasons := check(runs[max(0, i-globalWindow):i], scen[max(0, len(scen)-scenarioWindow):], r); len(reasons) > 0 {
+			out[r.ID] = reasons
+		}
+		byScenario[r.Scenario] = append(scen, r)
+	}
+	for _, s := range settings {
+		for _, id := range lastingChanges(runs, s.key) {
+			out[id] = drop(out[id], s.code)
+			if len(out[id]) == 0 {
+				delete(out, id)
+			}
+		}
+	}
+	return out
+}
+
+// Settles reports whether r is the run that makes a changed setting the norm. The
+// runs of the change before it were flagged when played and need judging again.
+func Settles(prev []history.Run, r history.Run) bool {
+	global := prev[max(0, len(prev)-globalWindow):]
+	for _, s := range settings {
+		v, ok := stat(r, s.key)
+		if !ok {
+			continue
+		}
+		vs := append(values(global, s.key), v)
+		if n := streak(vs); n == settleRuns && n < len(vs) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastingChanges returns the IDs of runs in blocks of at least settleRuns consecutive
+// runs sharing a value of key.
+func lastingChanges(runs []history.Run, key string) []string {
+	var out, block []string
+	var cur float64
+	flush := func() {
+		if len(block) >= settleRuns {
+			out = append(out, block...)
+		}
+		block = block[:0]
+	}
+	for _, r := range runs {
+		v, ok := stat(r, key)
+		if !ok {
+			continue
+		}
+		if len(block) > 0 && v != cur {
+			flush()
+		}
+		cur = v
+		block = append(block, r.ID)
+	}
+	flush()
+	return out
+}
+
+func drop(reasons []models.AnomalyReason, code string) []models.AnomalyReason {
+	out := reasons[:0]
+	for _, a := range reasons {
+		if a.Code != code {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// Codes returns the reason codes of a flagged run.
+func Codes(reasons []models.AnomalyReason) []string {
+	if len(reasons) == 0 {
+		return nil
+	}
+	out := make([]string, len(reasons))
+	for i, a := range reasons {
+		out[i] = a.Code
+	}
+	return out
+}
+
+func check(global, scen []history.Run, r history.Run) []models.AnomalyReason {
+	var out []models.AnomalyReason
+	flag := func(code, key string, v, norm float64) {
+		out = append(out, models.AnomalyReason{Code: code, Stat: key, Value: v, Norm: norm})
+	}
+	if v, ok := stat(r, "Avg FPS"); ok && v > 0 {
+		if norm, ok := median(global, "Avg FPS"); ok && v < norm*fpsDropRatio {
+			flag(ReasonFPSDrop, "Avg FPS", v, norm)
+		}
+	}
+	for _, s := range settings {
+		if v, ok := stat(r, s.key); ok {
+			if norm, ok := settingNorm(global, s.key); ok && v != norm {
+				flag(s.code, s.key, v, norm)
+			}
+		}
+	}
+	// Time dilation is 1 unless the game falls behind, so it defaults to 1 until the
+	// scenario has enough history; target scale legitimately differs per scenario.
+	if v, ok := stat(r, "Avg Time Dilation"); ok {
+		norm, ok := median(scen, "Avg Time Dilation")
+		if !ok {
+			norm = 1
+		}
+		if math.Abs(v-norm) > dilationTolerance {
+			flag(ReasonTimeDilation, "Avg Time Dilation", v, norm)
+		}
+	}
+	if v, ok := stat(r, "Avg Target Scale"); ok {
+		if norm, ok := median(scen, "Avg Target Scale"); ok && norm > 0 && math.Abs(v/norm-1) > targetScaleTolerance {
+			flag(ReasonTargetScale, "Avg Target Scale", v, norm)
+		}
+	}
+	return out
+}
+
+func stat(r history.Run, key string) (float64, bool) {
+	v, ok := r.Stats[key]
+	if !ok {
+		return 0, false
+	}
+	f := util.ToFloat(v)
+	return f, !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+func values(runs []history.Run, key string) []float64 {
+	var out []float64
+	for _, r := range runs {
+		if v, ok := stat(r, key); ok {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func median(runs []history.Run, key string) (float64, bool) {
+	vs := values(runs, key)
+	if len(vs) < minHistory {
+		return 0, false
+	}
+	return util.Median(vs), true
+}
+
+// settingNorm returns the usual value of a setting: the latest one once it has held
+// for settleRuns runs in a row, otherwise the most common one.
+func settingNorm(runs []history.Run, key string) (float64, bool) {
+	vs := values(runs, key)
+	if len(vs) < minHistory {
+		return 0, false
+	}
+	if streak(vs) >= settleRuns {
+		return vs[len(vs)-1], true
+	}
+	return mode(vs), true
+}
+
+// streak counts how many of the last values equal the final one.
+func streak(vs []float64) int {
+	n := 0
+	for i := len(vs) - 1; i >= 0 && vs[i] == vs[len(vs)-1]; i-- {
+		n++
+	}
+	return n
+}
+
+// mode returns the most common value, preferring the most recent on ties.
+func mode(vs []float64) float64 {
+	counts := make(map[float64]int)
+	best, bestN := 0.0, 0
+	for i := len(vs) - 1; i >= 0; i-- {
+		v := vs[i]
+		counts[v]++
+		if counts[v] > bestN {
+			best, bestN = v, counts[v]
+		}
+	}
+	return best
+}
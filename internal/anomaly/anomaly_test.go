@@ -0,0 +1,92 @@
+package anomaly
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"refleks/internal/history"
+)
+
+func TestDetect(t *testing.T) {
+	start := time.Date(2025, 10, 1, 18, 0, 0, 0, time.Local)
+	var runs []history.Run
+	play := func(scenario string, tweak func(map[string]any)) {
+		stats := map[string]any{
+			"Avg FPS": 300.0, "Input Lag": 0.0, "Resolution Scale": 100.0,
+			"Avg Time Dilation": 1.0, "Avg Target Scale": 1.0,
+		}
+		if tweak != nil {
+			tweak(stats)
+		}
+		end := start.Add(time.Duration(len(runs)) * time.Minute)
+		runs = append(runs, history.Run{ID: fmt.Sprint(len(runs)), Scenario: scenario, End: end, Stats: stats})
+	}
+	// Too little history: an FPS drop on the second run cannot be judged yet.
+	play("A", nil)
+	play("A", func(s map[string]any) { s["Avg FPS"] = 120.0 })
+	for i := 0; i < 8; i++ {
+		play("A", nil)
+	}
+	play("A", func(s map[string]any) { s["Avg FPS"] = 200.0; s["Avg Time Dilation"] = 0.9 })
+	// A scenario with its own target scale is judged against its own history.
+	for i := 0; i < 5; i++ {
+		play("B", func(s map[string]any) { s["Avg Target Scale"] = 0.5 })
+	}
+	play("B", func(s map[string]any) { s["Avg Target Scale"] = 0.6; s["Input Lag"] = 1.0 })
+
+	flags := Detect(runs)
+	if len(flags) != 2 {
+		t.Fatalf("flagged %d runs, want 2: %+v", len(flags), flags)
+	}
+	if got := Codes(flags["10"]); !reflect.DeepEqual(got, []string{ReasonFPSDrop, ReasonTimeDilation}) {
+		t.Errorf("run 10 reasons = %v", got)
+	}
+	if r := flags["10"][0]; r.Stat != "Avg FPS" || r.Value != 200 || r.Norm != 300 {
+		t.Errorf("unexpected FPS reason %+v", r)
+	}
+	if got := Codes(flags["16"]); !reflect.DeepEqual(got, []string{ReasonInputLag, ReasonTargetScale}) {
+		t.Errorf("run 16 reasons = %v", got)
+	}
+
+	if got := Codes(Check(runs[:10], runs[10])); !reflect.DeepEqual(got, Codes(flags["10"])) {
+		t.Errorf("Check = %v, want the same as Detect", got)
+	}
+}
+
+func TestSettingChange(t *testing.T) {
+	start := time.Date(2025, 10, 1, 18, 0, 0, 0, time.Local)
+	var runs []history.Run
+	play := func(lag, res float64) {
+		end := start.Add(time.Duration(len(runs)) * time.Minute)
+		stats := map[string]any{"Avg FPS": 300.0, "Input Lag": lag, "Resolution Scale": res}
+		runs = append(runs, history.Run{ID: fmt.Sprint(len(runs)), Scenario: "A", End: end, Stats: stats})
+	}
+	for i := 0; i < 30; i++ {
+		play(0, 100)
+	}
+	// A one-run resolution glitch that reverts, then input lag changed for good.
+	play(0, 50)
+	for i := 0; i < 30; i++ {
+		play(1, 100)
+	}
+
+	flags := Detect(runs)
+	if len(flags) != 1 || !reflect.DeepEqual(Codes(flags["30"]), []string{ReasonResolutionScale}) {
+		t.Fatalf("only the reverted glitch should be flagged, got %+v", flags)
+	}
+
+	// Played live, the first runs of the change are flagged until it settles.
+	for i := 31; i < 31+settleRuns; i++ {
+		if got := Codes(Check(runs[:i], runs[i])); !reflect.DeepEqual(got, []string{ReasonInputLag}) {
+			t.Errorf("run %d: Check = %v, want input lag", i, got)
+		}
+		if settles := Settles(runs[:i], runs[i]); settles != (i == 31+settleRuns-1) {
+			t.Errorf("run %d: Settles = %v", i, settles)
+		}
+	}
+	if got := Check(runs[:31+settleRuns], runs[31+settleRuns]); len(got) != 0 {
+		t.Errorf("a settled change should not be flagged, got %v", Codes(got))
+	}
+}
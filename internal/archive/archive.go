@@ -0,0 +1,478 @@
+package archive
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"refleks/internal/models"
+	"refleks/internal/parser"
+	appsettings "refleks/internal/settings"
+	"refleks/internal/util"
+)
+
+// Old stats files are moved into one zip per month under $HOME/.refleks/archive.
+// An index.json next to the zips records every archived file so history can be
+// rebuilt without opening each archive, and nothing is dropped silently.
+
+const (
+	indexFileName = "index.json"
+	monthLayout   = "2006-01"
+)
+
+// mu serializes archive/restore operations and index access.
+var mu sync.Mutex
+
+type index struct {
+	Version int                   `json:"version"`
+	Entries []models.ArchiveEntry `json:"entries"`
+}
+
+type candidate struct {
+	name    string
+	info    parser.FilenameInfo
+	size    int64
+	modTime time.Time
+}
+
+// dir returns the archive directory, creating it if needed.
+func dir() (string, error) {
+	d, err := appsettings.DefaultArchiveDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(d, 0o755); err != nil {
+		return "", err
+	}
+	return d, nil
+}
+
+// archiveName returns the zip file name for a YYYY-MM month bucket.
+func archiveName(month string) string {
+	return "stats-" + month + ".zip"
+}
+
+func loadIndex(d string) (index, error) {
+	b, err := os.ReadFile(filepath.Join(d, indexFileName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return index{Version: 1}, nil
+		}
+		return index{}, err
+	}
+	var idx index
+	if err := json.Unmarshal(b, &idx); err != nil {
+		return index{}, err
+	}
+	return idx, nil
+}
+
+// saveIndex writes the index atomically (temp file + rename).
+func saveIndex(d string, idx index) error {
+	idx.Version = 1
+	sort.Slice(idx.Entries, func(i, j int) bool { return idx.Entries[i].DatePlayed < idx.Entries[j].DatePlayed })
+	return util.SaveJSON(filepath.Join(d, indexFileName), idx)
+}
+
+// Entries returns every archived stats file recorded in the index.
+func Entries() ([]models.ArchiveEntry, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	d, err := dir()
+	if err != nil {
+		return nil, err
+	}
+	idx, err := loadIndex(d)
+	if err != nil {
+		return nil, err
+	}
+	return idx.Entries, nil
+}
+
+// Path returns a display path for an archived entry: the zip path joined with the entry name.
+func Path(e models.ArchiveEntry) string {
+	d, err := appsettings.DefaultArchiveDir()
+	if err != nil {
+		return filepath.Join(e.Archive, e.FileName)
+	}
+	return filepath.Join(d, e.Archive, e.FileName)
+}
+
+// Read returns the raw contents of an archived stats file. Stats files are small,
+// so the whole entry is read into memory rather than holding the zip open.
+func Read(e models.ArchiveEntry) ([]byte, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	d, err := dir()
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.OpenReader(filepath.Join(d, e.Archive))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	for _, f := range zr.File {
+		if f.Name != e.FileName {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	return nil, fmt.Errorf("%s not found in %s", e.FileName, e.Archive)
+}
+
+// ReadAll returns the contents of every file in one monthly archive (by its zip
+// name, as in ArchiveEntry.Archive), keyed by file name. Scans that read many
+// entries use it to open each zip once instead of once per entry.
+func ReadAll(name string) (map[string][]byte, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	d, err := dir()
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.OpenReader(filepath.Join(d, name))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	out := make(map[string][]byte, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		out[f.Name] = b
+	}
+	return out, nil
+}
+
+// Archive moves stats files in statsDir played before cutoff into monthly archives.
+// With dryRun set, nothing is touched and the result reports what would be archived.
+// Source files are only removed once both the zip and the index have been written.
+func Archive(statsDir string, cutoff time.Time, dryRun bool) (models.ArchiveResult, error) {
+	res := models.ArchiveResult{DryRun: dryRun}
+	entries, err := os.ReadDir(statsDir)
+	if err != nil {
+		return res, err
+	}
+	byMonth := make(map[string][]candidate)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		info, err := parser.ParseFilename(e.Name())
+		if err != nil || !info.DatePlayed.Before(cutoff) {
+			continue
+		}
+		fi, err := e.Info()
+		if err != nil {
+			res.Errors = append(res.Errors, fmt.Sprintf("%s: %v", e.Name(), err))
+			continue
+		}
+		month := info.DatePlayed.Format(monthLayout)
+		byMonth[month] = append(byMonth[month], candidate{name: e.Name(), info: info, size: fi.Size(), modTime: fi.ModTime()})
+	}
+	months := make([]string, 0, len(byMonth))
+	for m := range byMonth {
+		months = append(months, m)
+	}
+	sort.Strings(months)
+	res.Months = months
+	if dryRun {
+		for _, m := range months {
+			for _, c := range byMonth[m] {
+				res.Files++
+				res.Bytes += c.size
+			}
+		}
+		return res, nil
+	}
+	if len(months) == 0 {
+		return res, nil
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	d, err := dir()
+	if err != nil {
+		return res, err
+	}
+	idx, err := loadIndex(d)
+	if err != nil {
+		return res, err
+	}
+	archivedAt := time.Now().Format(time.RFC3339)
+	for _, month := range months {
+		files := byMonth[month]
+		name := archiveName(month)
+		incoming := make(map[string]bool, len(files))
+		for _, c := range files {
+			incoming[c.name] = true
+		}
+		// Re-archiving a name that already exists replaces the older copy.
+		_, err := rewrite(filepath.Join(d, name),
+			func(f *zip.File) bool { return !incoming[f.Name] },
+			func(zw *zip.Writer) (int, error) {
+				for _, c := range files {
+					if err := addFile(zw, filepath.Join(statsDir, c.name), c); err != nil {
+						return 0, err
+					}
+				}
+				return len(files), nil
+			})
+		if err != nil {
+			return res, fmt.Errorf("archive %s: %w", name, err)
+		}
+		kept := idx.Entries[:0]
+		for _, e := range idx.Entries {
+			if !incoming[e.FileName] {
+				kept = append(kept, e)
+			}
+		}
+		idx.Entries = kept
+		for _, c := range files {
+			idx.Entries = append(idx.Entries, models.ArchiveEntry{
+				FileName:     c.name,
+				ScenarioName: c.info.ScenarioName,
+				DatePlayed:   c.info.DatePlayed.Format(time.RFC3339),
+				Month:        month,
+				Archive:      name,
+				Size:         c.size,
+				ArchivedAt:   archivedAt,
+			})
+		}
+		if err := saveIndex(d, idx); err != nil {
+			return res, err
+		}
+		for _, c := range files {
+			if err := os.Remove(filepath.Join(statsDir, c.name)); err != nil {
+				res.Errors = append(res.Errors, fmt.Sprintf("%s: %v", c.name, err))
+				continue
+			}
+			res.Files++
+			res.Bytes += c.size
+		}
+	}
+	return res, nil
+}
+
+// Restore extracts archived stats files back into statsDir. When months is empty,
+// every archived file is restored. Files already present in statsDir are left as-is.
+func Restore(statsDir string, months []string, dryRun bool) (models.ArchiveResult, error) {
+	res := models.ArchiveResult{DryRun: dryRun}
+	mu.Lock()
+	defer mu.Unlock()
+	d, err := dir()
+	if err != nil {
+		return res, err
+	}
+	idx, err := loadIndex(d)
+	if err != nil {
+		return res, err
+	}
+	want := make(map[string]bool, len(months))
+	for _, m := range months {
+		want[m] = true
+	}
+	byArchive := make(map[string]map[string]models.ArchiveEntry)
+	monthSet := make(map[string]struct{})
+	for _, e := range idx.Entries {
+		if len(want) > 0 && !want[e.Month] {
+			continue
+		}
+		if byArchive[e.Archive] == nil {
+			byArchive[e.Archive] = make(map[string]models.ArchiveEntry)
+		}
+		byArchive[e.Archive][e.FileName] = e
+		monthSet[e.Month] = struct{}{}
+		if dryRun {
+			res.Files++
+			res.Bytes += e.Size
+		}
+	}
+	for m := range monthSet {
+		res.Months = append(res.Months, m)
+	}
+	sort.Strings(res.Months)
+	if dryRun || len(byArchive) == 0 {
+		return res, nil
+	}
+	if fi, err := os.Stat(statsDir); err != nil || !fi.IsDir() {
+		return res, fmt.Errorf("stats directory not accessible: %s", statsDir)
+	}
+
+	names := make([]string, 0, len(byArchive))
+	for name := range byArchive {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		selected := byArchive[name]
+		restored := make(map[string]bool, len(selected))
+		path := filepath.Join(d, name)
+		zr, err := zip.OpenReader(path)
+		if err != nil {
+			res.Errors = append(res.Errors, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		for _, f := range zr.File {
+			e, ok := selected[f.Name]
+			if !ok {
+				continue
+			}
+			dst := filepath.Join(statsDir, filepath.Base(f.Name))
+			if _, err := os.Stat(dst); err != nil {
+				if err := extractFile(f, dst); err != nil {
+					res.Errors = append(res.Errors, fmt.Sprintf("%s: %v", f.Name, err))
+					continue
+				}
+			}
+			restored[f.Name] = true
+			res.Files++
+			res.Bytes += e.Size
+		}
+		zr.Close()
+		for fn := range selected {
+			if !restored[fn] {
+				res.Errors = append(res.Errors, fmt.Sprintf("%s: not restored from %s", fn, name))
+			}
+		}
+		if len(restored) == 0 {
+			continue
+		}
+		remaining, err := rewrite(path, func(f *zip.File) bool { return !restored[f.Name] }, nil)
+		if err != nil {
+			return res, fmt.Errorf("rewrite %s: %w", name, err)
+		}
+		if remaining == 0 {
+			_ = os.Remove(path)
+		}
+		kept := idx.Entries[:0]
+		for _, e := range idx.Entries {
+			if e.Archive == name && restored[e.FileName] {
+				continue
+			}
+			kept = append(kept, e)
+		}
+		idx.Entries = kept
+		if err := saveIndex(d, idx); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// rewrite produces a new version of the zip at path containing the existing entries
+// accepted by keep plus anything written by add, then atomically replaces the original.
+// Kept entries are copied without recompression. Returns the resulting entry count.
+func rewrite(path string, keep func(*zip.File) bool, add func(*zip.Writer) (int, error)) (int, error) {
+	tmp := path + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return 0, err
+	}
+	fail := func(err error) (int, error) {
+		out.Close()
+		os.Remove(tmp)
+		return 0, err
+	}
+	zw := zip.NewWriter(out)
+	count := 0
+	zr, err := zip.OpenReader(path)
+	if err == nil {
+		for _, f := range zr.File {
+			if !keep(f) {
+				continue
+			}
+			if err := zw.Copy(f); err != nil {
+				zr.Close()
+				return fail(err)
+			}
+			count++
+		}
+		zr.Close()
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fail(err)
+	}
+	if add != nil {
+		n, err := add(zw)
+		if err != nil {
+			return fail(err)
+		}
+		count += n
+	}
+	if err := zw.Close(); err != nil {
+		return fail(err)
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(tmp)
+		return 0, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return 0, err
+	}
+	return count, nil
+}
+
+// addFile compresses a single stats file into the archive.
+func addFile(zw *zip.Writer, src string, c candidate) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: c.name, Method: zip.Deflate, Modified: c.modTime})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// extractFile writes a zip entry to dst via a temp file, preserving its modification time.
+func extractFile(f *zip.File, dst string) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	tmp := dst + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, rc); err != nil {
+		out.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	_ = os.Chtimes(dst, f.Modified, f.Modified)
+	return nil
+}
@@ -0,0 +1,69 @@
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestArchiveAndRestoreRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	statsDir := t.TempDir()
+	files := map[string]string{
+		"Old A - Challenge - 2025.08.01-10.00.00 Stats.csv": "Score:,1\n",
+		"Old B - Challenge - 2025.09.15-10.00.00 Stats.csv": "Score:,2\n",
+		"New C - Challenge - 2025.10.20-10.00.00 Stats.csv": "Score:,3\n",
+	}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(statsDir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cutoff := time.Date(2025, 10, 1, 0, 0, 0, 0, time.Local)
+
+	dry, err := Archive(statsDir, cutoff, true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if dry.Files != 2 || len(dry.Months) != 2 {
+		t.Fatalf("dry run: expected 2 files in 2 months, got %+v", dry)
+	}
+	if left, _ := os.ReadDir(statsDir); len(left) != 3 {
+		t.Fatalf("dry run must not move files, %d left", len(left))
+	}
+
+	res, err := Archive(statsDir, cutoff, false)
+	if err != nil {
+		t.Fatalf("archive: %v", err)
+	}
+	if res.Files != 2 {
+		t.Fatalf("expected 2 archived files, got %+v", res)
+	}
+	if left, _ := os.ReadDir(statsDir); len(left) != 1 {
+		t.Fatalf("expected 1 file left in stats dir, got %d", len(left))
+	}
+	entries, err := Entries()
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("expected 2 index entries, got %d (%v)", len(entries), err)
+	}
+	b, err := Read(entries[0])
+	if err != nil || string(b) != "Score:,1\n" {
+		t.Fatalf("read archived entry: %q %v", b, err)
+	}
+	all, err := ReadAll(entries[1].Archive)
+	if err != nil || len(all) != 1 || string(all[entries[1].FileName]) != "Score:,2\n" {
+		t.Fatalf("read whole archive: %v %v", all, err)
+	}
+
+	res, err = Restore(statsDir, []string{"2025-08"}, false)
+	if err != nil || res.Files != 1 {
+		t.Fatalf("restore: %+v %v", res, err)
+	}
+	if _, err := os.Stat(filepath.Join(statsDir, "Old A - Challenge - 2025.08.01-10.00.00 Stats.csv")); err != nil {
+		t.Fatalf("restored file missing: %v", err)
+	}
+	if entries, _ := Entries(); len(entries) != 1 || entries[0].Month != "2025-09" {
+		t.Fatalf("expected only the 2025-09 entry to remain, got %+v", entries)
+	}
+}
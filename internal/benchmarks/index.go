@@ -0,0 +1,190 @@
+package benchmarks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+	"refleks/internal/settings"
+	"refleks/internal/util"
+)
+
+// IndexEntry is what is known about a benchmark scenario: the benchmark category it
+// belongs to and the score needed for each rank, lowest rank first.
+type IndexEntry struct {
+	Category   string    `json:"category"`
+	Thresholds []float64 `json:"thresholds"`
+}
+
+// The embedded benchmark list carries category names and scenario counts but not the
+// scenarios themselves, so the index is learned from player progress responses and
+// kept on disk for analyses that run without network access.
+var indexMu sync.Mutex
+
+func indexPath() (string, error) {
+	base, err := settings.ConfigBaseDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, constants.BenchmarkIndexFileName), nil
+}
+
+// LoadIndex returns the scenario index, empty when nothing has been learned yet.
+func LoadIndex() map[string]IndexEntry {
+	indexMu.Lock()
+	defer indexMu.Unlock()
+	return loadIndexLocked()
+}
+
+func loadIndexLocked() map[string]IndexEntry {
+	out := make(map[string]IndexEntry)
+	p, err := indexPath()
+	if err != nil {
+		return out
+	}
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return out
+	}
+	_ = json.Unmarshal(b, &out)
+	return out
+}
+
+// UpdateIndex records the scenarios of a player progress response for benchmarkID.
+func UpdateIndex(benchmarkID int, raw string) error {
+	diff, ok := findDifficulty(benchmarkID)
+	if !ok {
+		return fmt.Errorf("unknown benchmark id %d", benchmarkID)
+	}
+	scen, err := progressScenarios(raw)
+	if err != nil {
+		return err
+	}
+	entries := indexProgress(diff, scen)
+
+	indexMu.Lock()
+	defer indexMu.Unlock()
+	idx := loadIndexLocked()
+	for name, e := range entries {
+		idx[name] = e
+	}
+	p, err := indexPath()
+	if err != nil {
+		return err
+	}
+	return util.SaveJSON(p, idx)
+}
+
+func findDifficulty(benchmarkID int) (models.BenchmarkDifficulty, bool) {
+	list, err := GetBenchmarks()
+	if err != nil {
+		return models.BenchmarkDifficulty{}, false
+	}
+	for _, b := range list {
+		for _, d := range b.Difficulties {
+			if d.KovaaksBenchmarkID == benchmarkID {
+				return d, true
+			}
+		}
+	}
+	return models.BenchmarkDifficulty{}, false
+}
+
+// progressScenario is one scenario of a player progress response, in response order.
+type progressScenario struct {
+	Name      string
+	RankMaxes []float64
+}
+
+// indexProgress assigns progress scenarios to the difficulty's categories. Like the
+// Explore UI, it maps strictly by order and subcategory scenario counts and ignores
+// the category names in the API response.
+func indexProgress(diff models.BenchmarkDifficulty, scen []progressScenario) map[string]IndexEntry {
+	out := make(map[string]IndexEntry, len(scen))
+	pos := 0
+	for _, c := range diff.Categories {
+		name, _ := c["categoryName"].(string)
+		subs, _ := c["subcategories"].([]any)
+		count := 0
+		for _, s := range subs {
+			if m, ok := s.(map[string]any); ok {
+				if n, ok := m["scenarioCount"].(float64); ok {
+					count += int(n)
+				}
+			}
+		}
+		for i := 0; i < count && pos < len(scen); i++ {
+			out[scen[pos].Name] = IndexEntry{Category: strings.TrimSpace(name), Thresholds: scen[pos].RankMaxes}
+			pos++
+		}
+	}
+	return out
+}
+
+// progressScenarios lists the scenarios of a player progress response in document
+// order, which a plain map decode would lose.
+func progressScenarios(raw string) ([]progressScenario, error) {
+	dec := json.NewDecoder(strings.NewReader(raw))
+	var out []progressScenario
+	err := walkObject(dec, func(key string) error {
+		if key != "categories" {
+			return skip(dec)
+		}
+		return walkObject(dec, func(string) error {
+			return walkObject(dec, func(key string) error {
+				if key != "scenarios" {
+					return skip(dec)
+				}
+				return walkObject(dec, func(name string) error {
+					var s struct {
+						RankMaxes []float64 `json:"rank_maxes"`
+					}
+					if err := dec.Decode(&s); err != nil {
+						return err
+					}
+					out = append(out, progressScenario{Name: name, RankMaxes: s.RankMaxes})
+					return nil
+				})
+			})
+		})
+	})
+	return out, err
+}
+
+// walkObject reads a JSON object from dec, calling fn with each key; fn must consume the value.
+func walkObject(dec *json.Decoder, fn func(key string) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return errors.New("expected JSON object")
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	if err == io.EOF {
+		err = nil
+	}
+	return err
+}
+
+func skip(dec *json.Decoder) error {
+	var v json.RawMessage
+	return dec.Decode(&v)
+}
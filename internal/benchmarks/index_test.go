@@ -0,0 +1,50 @@
+package benchmarks
+
+import (
+	"reflect"
+	"testing"
+
+	"refleks/internal/models"
+)
+
+// The API's category names are deliberately wrong to check they are ignored.
+const progressJSON = `{
+	"overall_rank": 2,
+	"categories": {
+		"x": {"category_rank": 1, "scenarios": {
+			"Zeta Click": {"score": 120000, "rank_maxes": [1000, 1100]},
+			"Alpha Click": {"score": 90000, "rank_maxes": [800, 900]}
+		}},
+		"y": {"scenarios": {
+			"Beta Track": {"score": 300000, "rank_maxes": [2500, 3000]}
+		}}
+	}
+}`
+
+func TestIndexProgress(t *testing.T) {
+	scen, err := progressScenarios(progressJSON)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	names := []string{}
+	for _, s := range scen {
+		names = append(names, s.Name)
+	}
+	if want := []string{"Zeta Click", "Alpha Click", "Beta Track"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("scenarios out of document order: %v", names)
+	}
+
+	diff := models.BenchmarkDifficulty{Categories: []map[string]any{
+		{"categoryName": "Clicking", "subcategories": []any{map[string]any{"scenarioCount": 1.0}, map[string]any{"scenarioCount": 1.0}}},
+		{"categoryName": "Tracking", "subcategories": []any{map[string]any{"scenarioCount": 1.0}}},
+	}}
+	got := indexProgress(diff, scen)
+	want := map[string]IndexEntry{
+		"Zeta Click":  {Category: "Clicking", Thresholds: []float64{1000, 1100}},
+		"Alpha Click": {Category: "Clicking", Thresholds: []float64{800, 900}},
+		"Beta Track":  {Category: "Tracking", Thresholds: []float64{2500, 3000}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("index = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,116 @@
+package compare
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"refleks/internal/history"
+	"refleks/internal/killstats"
+	"refleks/internal/kinematics"
+	"refleks/internal/models"
+	"refleks/internal/sens"
+	"refleks/internal/util"
+)
+
+// Run is one side of a comparison: the run summary plus what only the full stats
+// file and trace provide.
+type Run struct {
+	history.Run
+	// Kills are the kill timestamps in order.
+	Kills []time.Time
+	Trace []models.MousePoint
+}
+
+// statKeys are compared when present in both runs, in this order.
+var statKeys = []string{
+	"Score", "Accuracy", "Real Avg TTK", "Avg TTK", "Kills", "Hit Count", "Miss Count",
+	"Damage Done", "Damage Possible", "Damage Taken", "Total Overshots",
+	killstats.KeyIntervalCV, killstats.KeySlowestInterval, killstats.KeyPaceChange,
+	"Avg FPS",
+}
+
+// setupKeys are settings compared as text.
+var setupKeys = []string{
+	"Sens Scale", "Horiz Sens", "Vert Sens", "DPI", "cm/360", "FOV", "FOVScale",
+	"Resolution", "Resolution Scale", "Crosshair", "Crosshair Scale", "Crosshair Color",
+	"Max FPS (config)", "Input Lag", "Hide Gun", "Game Version", "Hash",
+}
+
+// Compare puts run b against run a.
+func Compare(a, b Run) models.RunComparison {
+	out := models.RunComparison{A: ref(a), B: ref(b)}
+	for _, k := range statKeys {
+		va, okA := number(a, k)
+		vb, okB := number(b, k)
+		if okA && okB {
+			out.Stats = append(out.Stats, diff(k, va, vb))
+		}
+	}
+	for i := 0; i < len(a.Kills) && i < len(b.Kills); i++ {
+		ta := a.Kills[i].Sub(a.Start).Seconds()
+		tb := b.Kills[i].Sub(b.Start).Seconds()
+		out.Kills = append(out.Kills, models.KillDelta{Index: i + 1, A: ta, B: tb, Delta: tb - ta})
+	}
+	for _, k := range setupKeys {
+		va, okA := setting(a, k)
+		vb, okB := setting(b, k)
+		if okA && okB && va != vb {
+			out.Setup = append(out.Setup, models.SetupChange{Key: k, Before: va, After: vb})
+		}
+	}
+	if len(a.Trace) > 1 && len(b.Trace) > 1 {
+		sa, okA := sens.ScaleFromStats(a.Stats, sens.UnitDegrees)
+		sb, okB := sens.ScaleFromStats(b.Stats, sens.UnitDegrees)
+		if !okA || !okB {
+			sa, sb = sens.Counts, sens.Counts
+		}
+		ma, mb := kinematics.Summarize(a.Trace, sa), kinematics.Summarize(b.Trace, sb)
+		out.Trace = &models.TraceComparison{Unit: sa.Unit, A: ma, B: mb, Diffs: []models.StatDiff{
+			diff("Duration", ma.DurationSec, mb.DurationSec),
+			diff("Path Length", ma.PathLength, mb.PathLength),
+			diff("Mean Speed", ma.MeanSpeed, mb.MeanSpeed),
+			diff("Peak Speed", ma.PeakSpeed, mb.PeakSpeed),
+			diff("Direction Changes", float64(ma.DirectionChanges), float64(mb.DirectionChanges)),
+		}}
+	}
+	return out
+}
+
+func ref(r Run) models.RunRef {
+	return models.RunRef{ID: r.ID, Scenario: r.Scenario, Played: r.End.Format(time.RFC3339), Score: r.Score}
+}
+
+func diff(key string, a, b float64) models.StatDiff {
+	d := models.StatDiff{Key: key, A: a, B: b, Delta: b - a}
+	if a != 0 {
+		d.Rel = b/a - 1
+	}
+	return d
+}
+
+func number(r Run, key string) (float64, bool) {
+	if key == "Score" {
+		return r.Score, true
+	}
+	v, ok := r.Stats[key]
+	if !ok {
+		return 0, false
+	}
+	f := util.ToFloat(v)
+	return f, !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// setting formats a setting for comparison; cm/360 is rounded so float noise is not
+// reported as a change.
+func setting(r Run, key string) (string, bool) {
+	v, ok := r.Stats[key]
+	if !ok {
+		return "", false
+	}
+	if key == "cm/360" {
+		return strconv.FormatFloat(math.Round(util.ToFloat(v)*10)/10, 'f', 1, 64), true
+	}
+	return fmt.Sprint(v), true
+}
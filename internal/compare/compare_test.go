@@ -0,0 +1,52 @@
+package compare
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"refleks/internal/history"
+	"refleks/internal/models"
+)
+
+func TestCompare(t *testing.T) {
+	start := time.Date(2025, 10, 1, 18, 0, 0, 0, time.Local)
+	run := func(id string, score, acc float64, cm any, kills ...float64) Run {
+		r := Run{Run: history.Run{ID: id, Scenario: "A", Start: start, End: start.Add(time.Minute), Score: score,
+			Stats: map[string]any{"Accuracy": acc, "cm/360": cm, "FOV": 103}}}
+		for _, k := range kills {
+			r.Kills = append(r.Kills, start.Add(time.Duration(k*float64(time.Second))))
+		}
+		return r
+	}
+	a := run("a", 1000, 0.5, 34.01, 1, 2, 3)
+	b := run("b", 1100, 0.6, 34.04, 1.5, 2.5)
+
+	c := Compare(a, b)
+	if c.A.ID != "a" || c.B.ID != "b" {
+		t.Fatalf("unexpected refs %+v / %+v", c.A, c.B)
+	}
+	if len(c.Stats) != 2 || c.Stats[0].Key != "Score" || c.Stats[0].Delta != 100 || math.Abs(c.Stats[0].Rel-0.1) > 1e-9 {
+		t.Errorf("unexpected stat diffs %+v", c.Stats)
+	}
+	if len(c.Kills) != 2 || c.Kills[1].Index != 2 || c.Kills[1].Delta != 0.5 {
+		t.Errorf("unexpected kill deltas %+v", c.Kills)
+	}
+	if len(c.Setup) != 0 {
+		t.Errorf("rounding noise in cm/360 should not count as a change: %+v", c.Setup)
+	}
+	if c.Trace != nil {
+		t.Error("no trace comparison expected without traces")
+	}
+
+	b.Stats["FOV"] = 90
+	trace := []models.MousePoint{{TS: start, X: 0, Y: 0}, {TS: start.Add(time.Second), X: 30, Y: 40}}
+	a.Trace, b.Trace = trace, trace
+	c = Compare(a, b)
+	if len(c.Setup) != 1 || c.Setup[0].Key != "FOV" || c.Setup[0].Before != "103" || c.Setup[0].After != "90" {
+		t.Errorf("unexpected setup diffs %+v", c.Setup)
+	}
+	if c.Trace == nil || c.Trace.A.PathLength != 50 || c.Trace.A.MeanSpeed != 50 {
+		t.Errorf("unexpected trace comparison %+v", c.Trace)
+	}
+}
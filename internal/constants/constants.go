@@ -19,6 +19,8 @@ const (
 	DefaultTheme              = "dark"
 	DefaultMouseBufferMinutes = 10
 	DefaultMaxExistingOnStart = 500
+	// DefaultTrendRuns is how many recent runs feed a scenario's score trend slope.
+	DefaultTrendRuns = 20
 
 	// Watcher defaults
 	DefaultPollIntervalSeconds = 5
@@ -31,8 +33,17 @@ const (
 
 	// Settings + paths
 	// Name of the app config folder in the user's home directory
-	ConfigDirName    = ".refleks"
-	TracesSubdirName = "traces"
+	ConfigDirName     = ".refleks"
+	TracesSubdirName  = "traces"
+	ArchiveSubdirName = "archive"
+	// HistoryFileName is the append-only cache of compact run summaries (JSON lines).
+	HistoryFileName = "history.jsonl"
+	// BenchmarkIndexFileName maps scenarios to benchmark categories and rank thresholds.
+	BenchmarkIndexFileName = "benchmark_index.json"
+	// GoalsFileName holds the player's score, accuracy and TTK goals.
+	GoalsFileName = "goals.json"
+	// RoutinesFileName holds the player's training routines.
+	RoutinesFileName = "routines.json"
 
 	// Default Kovaak's stats directory on Windows
 	DefaultWindowsKovaaksStatsDir = `C:\\Program Files (x86)\\Steam\\steamapps\\common\\FPSAimTrainer\\FPSAimTrainer\\stats`
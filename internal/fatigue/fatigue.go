@@ -0,0 +1,272 @@
+package fatigue
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"refleks/internal/history"
+	"refleks/internal/models"
+	"refleks/internal/util"
+)
+
+// EventName is emitted by the watcher when the active session shows fatigue.
+const EventName = "FatigueDetected"
+
+const (
+	minSessions = 3
+	// minSamples is the fewest runs a curve bucket needs to be trusted.
+	minSamples = 3
+	// elapsedBin is the width of elapsed-time buckets in minutes.
+	elapsedBin = 5.0
+	// warmTolerance is how close to the peak (relative to baseline) counts as warmed up.
+	warmTolerance = 0.01
+	// fatigueSpreads is how many standard errors of a curve bucket the curve must stay
+	// below the warmed level to count as fatigue. A bucket averages at least
+	// minSamples runs, so its error is the scenario spread over sqrt(minSamples).
+	fatigueSpreads = 2.0
+	// minSpread floors the run-to-run spread so very consistent scenarios are not
+	// alerted on drops too small to matter.
+	minSpread = 0.01
+
+	// Live detection compares the median of the last liveWindow runs with the median
+	// of the liveEarly runs that follow the first liveSkip (cold) runs of the session,
+	// with each run measured in its scenario's run-to-run spreads. Medians keep a
+	// single bad run from raising or hiding an alert, and the two windows never overlap.
+	liveSkip    = 1
+	liveEarly   = 4
+	liveWindow  = 3
+	liveMinRuns = liveSkip + liveEarly + liveWindow
+	// liveSpreads is the drop, in spreads, that counts as significant. The difference
+	// of the two medians has a standard error of about one spread.
+	liveSpreads = 2.0
+)
+
+type sample struct {
+	position int
+	elapsed  float64 // minutes since the session started
+	rel      float64 // score relative to the scenario baseline
+}
+
+// Analyze builds a fatigue profile for every scenario in runs, sorted by name.
+// Runs must be oldest first; each scenario's norm is taken over all of them.
+func Analyze(runs []history.Run, gap time.Duration) []models.FatigueProfile {
+	norms := Norms(runs)
+	samples := make(map[string][]sample)
+	sessionCount := make(map[string]int)
+	for _, sess := range history.GroupSessions(runs, gap) {
+		start := sess[0].Start
+		seen := make(map[string]bool)
+		for i, r := range sess {
+			b := norms[r.Scenario].Baseline
+			if b <= 0 {
+				continue
+			}
+			samples[r.Scenario] = append(samples[r.Scenario], sample{
+				position: i + 1,
+				elapsed:  r.End.Sub(start).Minutes(),
+				rel:      r.Score/b - 1,
+			})
+			if !seen[r.Scenario] {
+				seen[r.Scenario] = true
+				sessionCount[r.Scenario]++
+			}
+		}
+	}
+	out := make([]models.FatigueProfile, 0, len(samples))
+	for name, ss := range samples {
+		out = append(out, profile(name, norms[name], sessionCount[name], ss))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Scenario < out[j].Scenario })
+	return out
+}
+
+// Norm is a scenario's typical score and how much it varies from run to run.
+type Norm struct {
+	// Baseline is the median score.
+	Baseline float64
+	// Spread is the median absolute change between consecutive runs relative to
+	// Baseline. Unlike a standard deviation it ignores long-term progress.
+	Spread float64
+}
+
+// Norms returns the norm of every scenario in runs, which must be oldest first.
+func Norms(runs []history.Run) map[string]Norm {
+	scores := make(map[string][]float64)
+	for _, r := range runs {
+		scores[r.Scenario] = append(scores[r.Scenario], r.Score)
+	}
+	out := make(map[string]Norm, len(scores))
+	for name, s := range scores {
+		diffs := make([]float64, 0, len(s))
+		for i := 1; i < len(s); i++ {
+			diffs = append(diffs, math.Abs(s[i]-s[i-1]))
+		}
+		n := Norm{Baseline: util.Median(s)}
+		if len(diffs) > 0 && n.Baseline > 0 {
+			n.Spread = util.Median(diffs) / n.Baseline
+		}
+		out[name] = n
+	}
+	return out
+}
+
+// spread returns the run-to-run spread used for significance, floored at minSpread.
+func (n Norm) spread() float64 {
+	return math.Max(n.Spread, minSpread)
+}
+
+func profile(name string, norm Norm, sessionCount int, ss []sample) models.FatigueProfile {
+	p := models.FatigueProfile{Scenario: name, Sessions: sessionCount, Runs: len(ss), Baseline: norm.Baseline}
+	if sessionCount < minSessions {
+		p.Reason = "Need at least 3 sessions with this scenario"
+		return p
+	}
+	p.ByPosition = curve(ss, func(s sample) float64 { return float64(s.position) })
+	p.ByElapsed = curve(ss, func(s sample) float64 { return math.Floor(s.elapsed/elapsedBin) * elapsedBin })
+	byPos := trusted(p.ByPosition)
+	if len(byPos) < 3 {
+		p.Reason = "Not enough runs at each session position"
+		return p
+	}
+	p.Sufficient = true
+	drop := fatigueSpreads * norm.spread() / math.Sqrt(minSamples)
+	warm, level, onset, decline := shape(smooth(byPos), drop)
+	p.WarmupRuns = int(warm)
+	p.WarmedLevel = level
+	p.FatigueOnsetRuns = int(onset)
+	p.FatigueDecline = decline
+	if byTime := trusted(p.ByElapsed); len(byTime) >= 3 {
+		p.WarmupMinutes, _, p.FatigueOnsetMinutes, _ = shape(smooth(byTime), drop)
+	}
+	return p
+}
+
+// curve buckets samples by key and averages them.
+func curve(ss []sample, key func(sample) float64) []models.CurvePoint {
+	sums := make(map[float64]float64)
+	counts := make(map[float64]int)
+	for _, s := range ss {
+		k := key(s)
+		sums[k] += s.rel
+		counts[k]++
+	}
+	out := make([]models.CurvePoint, 0, len(sums))
+	for k, sum := range sums {
+		out = append(out, models.CurvePoint{X: k, Mean: sum / float64(counts[k]), Count: counts[k]})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].X < out[j].X })
+	return out
+}
+
+// trusted keeps the leading buckets with enough samples; sparse tails are noise.
+func trusted(pts []models.CurvePoint) []models.CurvePoint {
+	for i, p := range pts {
+		if p.Count < minSamples {
+			return pts[:i]
+		}
+	}
+	return pts
+}
+
+// smooth applies a count-weighted three-point moving average.
+func smooth(pts []models.CurvePoint) []models.CurvePoint {
+	out := make([]models.CurvePoint, len(pts))
+	for i := range pts {
+		var sum float64
+		var n int
+		for j := max(0, i-1); j <= min(len(pts)-1, i+1); j++ {
+			sum += pts[j].Mean * float64(pts[j].Count)
+			n += pts[j].Count
+		}
+		out[i] = models.CurvePoint{X: pts[i].X, Mean: sum / float64(n), Count: pts[i].Count}
+	}
+	return out
+}
+
+// shape finds the warm-up point (first X within warmTolerance of the peak), the
+// warmed level (the peak) and, after the peak, the onset of a sustained decline of
+// at least drop together with how far the curve ends below the peak.
+func shape(pts []models.CurvePoint, drop float64) (warmX, level, onsetX, decline float64) {
+	peak := 0
+	for i, p := range pts {
+		if p.Mean > pts[peak].Mean {
+			peak = i
+		}
+	}
+	level = pts[peak].Mean
+	for _, p := range pts {
+		if p.Mean >= level-warmTolerance {
+			warmX = p.X
+			break
+		}
+	}
+	for i := peak + 1; i < len(pts); i++ {
+		sustained := true
+		for _, q := range pts[i:] {
+			if q.Mean > level-drop {
+				sustained = false
+				break
+			}
+		}
+		if sustained {
+			onsetX = pts[i].X
+			decline = level - pts[len(pts)-1].Mean
+			break
+		}
+	}
+	return warmX, level, onsetX, decline
+}
+
+// Monitor watches the active session and reports fatigue at most once per session.
+// Only one session is active at a time, so it remembers just the last one alerted.
+// It is safe for concurrent use.
+type Monitor struct {
+	mu      sync.Mutex
+	alerted string
+}
+
+// NewMonitor returns a monitor with no alerts raised.
+func NewMonitor() *Monitor {
+	return &Monitor{}
+}
+
+// Check evaluates the runs of an active session (oldest first) against each scenario's
+// norm and returns an alert when the latest runs fall significantly below the
+// early-session level, given how much those scenarios normally vary.
+func (m *Monitor) Check(sessionID string, runs []history.Run, norm func(scenario string) Norm) (models.FatigueAlert, bool) {
+	var rel, z []float64
+	for _, r := range runs {
+		if n := norm(r.Scenario); n.Baseline > 0 {
+			v := r.Score/n.Baseline - 1
+			rel = append(rel, v)
+			z = append(z, v/n.spread())
+		}
+	}
+	if len(rel) < liveMinRuns {
+		return models.FatigueAlert{}, false
+	}
+	early := func(vs []float64) float64 { return util.Median(vs[liveSkip : liveSkip+liveEarly]) }
+	late := func(vs []float64) float64 { return util.Median(vs[len(vs)-liveWindow:]) }
+	if early(z)-late(z) < liveSpreads {
+		return models.FatigueAlert{}, false
+	}
+	warmed, recent := early(rel), late(rel)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.alerted == sessionID {
+		return models.FatigueAlert{}, false
+	}
+	m.alerted = sessionID
+	last := runs[len(runs)-1]
+	return models.FatigueAlert{
+		SessionID:      sessionID,
+		RunID:          last.ID,
+		Runs:           len(runs),
+		ElapsedMinutes: last.End.Sub(runs[0].Start).Minutes(),
+		WarmedLevel:    warmed,
+		RecentLevel:    recent,
+		Drop:           warmed - recent,
+	}, true
+}
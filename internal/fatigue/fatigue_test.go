@@ -0,0 +1,114 @@
+package fatigue
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"refleks/internal/history"
+)
+
+// shapeByPosition is the per-position score multiplier: two warm-up runs, a plateau, then a decline.
+var shapeByPosition = []float64{0.90, 0.95, 1.00, 1.00, 1.00, 0.96, 0.93, 0.90}
+
+func TestAnalyze(t *testing.T) {
+	var runs []history.Run
+	day := time.Date(2025, 10, 1, 18, 0, 0, 0, time.UTC)
+	for s := 0; s < 4; s++ {
+		start := day.AddDate(0, 0, s)
+		for i, m := range shapeByPosition {
+			end := start.Add(time.Duration(i+1) * 2 * time.Minute)
+			runs = append(runs, history.Run{
+				ID:       fmt.Sprintf("%d-%d", s, i),
+				Scenario: "VT Ground Intermediate S5",
+				Start:    end.Add(-time.Minute),
+				End:      end,
+				Score:    1000 * m,
+			})
+		}
+	}
+	got := Analyze(runs, 15*time.Minute)
+	if len(got) != 1 || !got[0].Sufficient {
+		t.Fatalf("expected one sufficient profile, got %+v", got)
+	}
+	p := got[0]
+	if p.WarmupRuns < 2 || p.WarmupRuns > 4 {
+		t.Errorf("warm-up runs = %d, want 2..4", p.WarmupRuns)
+	}
+	if p.FatigueOnsetRuns < 6 || p.FatigueDecline <= 0 {
+		t.Errorf("expected fatigue from run 6 on, got onset %d decline %v", p.FatigueOnsetRuns, p.FatigueDecline)
+	}
+
+	if few := Analyze(runs[:8], 15*time.Minute); few[0].Sufficient || few[0].Reason == "" {
+		t.Errorf("a single session should be reported as insufficient: %+v", few[0])
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	start := time.Date(2025, 10, 1, 18, 0, 0, 0, time.UTC)
+	var runs []history.Run
+	for i, m := range shapeByPosition {
+		end := start.Add(time.Duration(i+1) * 2 * time.Minute)
+		runs = append(runs, history.Run{ID: fmt.Sprint(i), Scenario: "a", Start: end.Add(-time.Minute), End: end, Score: 1000 * m})
+	}
+	norm := func(string) Norm { return Norm{Baseline: 1000, Spread: 0.02} }
+	m := NewMonitor()
+	if _, ok := m.Check("s", runs[:6], norm); ok {
+		t.Fatal("no fatigue expected while still on the plateau")
+	}
+	alert, ok := m.Check("s", runs, norm)
+	if !ok || alert.RunID != "7" || alert.Drop < 0.05 {
+		t.Fatalf("expected fatigue on the last run, got %+v ok=%v", alert, ok)
+	}
+	if _, ok := m.Check("s", runs, norm); ok {
+		t.Fatal("fatigue should only be reported once per session")
+	}
+	if _, ok := m.Check("t", runs, norm); !ok {
+		t.Fatal("a new session should be able to raise its own alert")
+	}
+}
+
+func TestMonitorSingleBadRun(t *testing.T) {
+	start := time.Date(2025, 10, 1, 18, 0, 0, 0, time.UTC)
+	session := func(mults ...float64) []history.Run {
+		var runs []history.Run
+		for i, m := range mults {
+			end := start.Add(time.Duration(i+1) * 2 * time.Minute)
+			runs = append(runs, history.Run{ID: fmt.Sprint(i), Scenario: "a", Start: end.Add(-time.Minute), End: end, Score: 1000 * m})
+		}
+		return runs
+	}
+	norm := func(string) Norm { return Norm{Baseline: 1000, Spread: 0.02} }
+	if _, ok := NewMonitor().Check("s", session(0.90, 1.00, 1.00, 1.00, 1.00, 1.00, 0.80, 1.00), norm); ok {
+		t.Error("one bad recent run should not count as fatigue")
+	}
+	if _, ok := NewMonitor().Check("s", session(0.90, 0.80, 1.00, 1.00, 1.00, 0.96, 0.93, 0.90), norm); !ok {
+		t.Error("one bad early run should not hide a sustained decline")
+	}
+}
+
+func TestMonitorNoisyFlatSession(t *testing.T) {
+	start := time.Date(2025, 10, 1, 18, 0, 0, 0, time.UTC)
+	// Scores swing 8% either way with no trend; the last three happen to be mostly low.
+	mults := []float64{0.92, 1.08, 0.92, 1.08, 0.92, 1.08, 0.92, 1.08, 0.92, 0.92, 1.08, 0.92}
+	var runs []history.Run
+	for i, m := range mults {
+		end := start.Add(time.Duration(i+1) * 2 * time.Minute)
+		runs = append(runs, history.Run{ID: fmt.Sprint(i), Scenario: "a", Start: end.Add(-time.Minute), End: end, Score: 1000 * m})
+	}
+	n := Norms(runs)["a"]
+	if n.Spread < 0.1 {
+		t.Fatalf("alternating scores should have a wide spread, got %+v", n)
+	}
+	norm := func(string) Norm { return n }
+	for i := liveMinRuns; i <= len(runs); i++ {
+		if alert, ok := NewMonitor().Check("s", runs[:i], norm); ok {
+			t.Fatalf("a noisy but flat session should not alert after %d runs: %+v", i, alert)
+		}
+	}
+	// The same recent dip in a very consistent scenario is significant.
+	steady := func(string) Norm { return Norm{Baseline: 1000, Spread: 0.005} }
+	if _, ok := NewMonitor().Check("s", runs, steady); !ok {
+		t.Error("a dip far beyond a steady scenario's spread should alert")
+	}
+}
@@ -0,0 +1,162 @@
+package filters
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"refleks/internal/models"
+)
+
+// Rule actions accepted in models.ScenarioFilter.Action.
+const (
+	ActionExclude = "exclude"
+	ActionInclude = "include"
+)
+
+// Run holds the attributes filter rules are evaluated against.
+type Run struct {
+	Scenario string
+	Mode     string
+	Duration time.Duration
+}
+
+type rule struct {
+	include bool
+	re      *regexp.Regexp // nil matches every scenario
+	mode    string
+	minDur  time.Duration
+}
+
+// Set is a compiled, immutable collection of filter rules. A nil *Set hides nothing.
+type Set struct {
+	rules      []rule
+	hasInclude bool
+	hidden     map[string]struct{}
+}
+
+// Compile validates and compiles the persisted rules and hidden scenario names.
+func Compile(rules []models.ScenarioFilter, hidden []string) (*Set, error) {
+	s := &Set{hidden: make(map[string]struct{}, len(hidden))}
+	for _, name := range hidden {
+		if n := strings.TrimSpace(name); n != "" {
+			s.hidden[strings.ToLower(n)] = struct{}{}
+		}
+	}
+	for i, r := range rules {
+		var cr rule
+		switch strings.ToLower(strings.TrimSpace(r.Action)) {
+		case "", ActionExclude:
+		case ActionInclude:
+			cr.include = true
+			s.hasInclude = true
+		default:
+			return nil, fmt.Errorf("filter %d: unknown action %q", i+1, r.Action)
+		}
+		if p := strings.TrimSpace(r.Pattern); p != "" {
+			expr := globToRegex(p)
+			if r.Regex {
+				expr = p
+			}
+			re, err := regexp.Compile("(?i)" + expr)
+			if err != nil {
+				return nil, fmt.Errorf("filter %d: invalid pattern %q: %w", i+1, r.Pattern, err)
+			}
+			cr.re = re
+		}
+		cr.mode = strings.ToLower(strings.TrimSpace(r.Mode))
+		if r.MinDurationSeconds > 0 {
+			cr.minDur = time.Duration(r.MinDurationSeconds * float64(time.Second))
+		}
+		s.rules = append(s.rules, cr)
+	}
+	return s, nil
+}
+
+// Hidden reports whether a run should be tagged hidden.
+func (s *Set) Hidden(r Run) bool {
+	if s == nil {
+		return false
+	}
+	if _, ok := s.hidden[strings.ToLower(r.Scenario)]; ok {
+		return true
+	}
+	included := false
+	for _, cr := range s.rules {
+		if !cr.selects(r) {
+			continue
+		}
+		if cr.include {
+			if cr.minDur == 0 || r.Duration >= cr.minDur {
+				included = true
+			}
+			continue
+		}
+		if cr.minDur == 0 || r.Duration < cr.minDur {
+			return true
+		}
+	}
+	return s.hasInclude && !included
+}
+
+// Equal reports whether s and o were compiled from equivalent rules and hidden
+// names. A nil set equals an empty one.
+func (s *Set) Equal(o *Set) bool {
+	if s.empty() || o.empty() {
+		return s.empty() && o.empty()
+	}
+	if s.hasInclude != o.hasInclude || len(s.rules) != len(o.rules) || len(s.hidden) != len(o.hidden) {
+		return false
+	}
+	for name := range s.hidden {
+		if _, ok := o.hidden[name]; !ok {
+			return false
+		}
+	}
+	for i, a := range s.rules {
+		b := o.rules[i]
+		if a.include != b.include || a.mode != b.mode || a.minDur != b.minDur || a.pattern() != b.pattern() {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Set) empty() bool {
+	return s == nil || (len(s.rules) == 0 && len(s.hidden) == 0)
+}
+
+// pattern returns the compiled expression, empty when the rule matches every scenario.
+func (cr rule) pattern() string {
+	if cr.re == nil {
+		return ""
+	}
+	return cr.re.String()
+}
+
+// selects reports whether the rule's name and mode constraints match the run.
+func (cr rule) selects(r Run) bool {
+	if cr.mode != "" && cr.mode != strings.ToLower(r.Mode) {
+		return false
+	}
+	return cr.re == nil || cr.re.MatchString(r.Scenario)
+}
+
+// globToRegex converts a glob with * and ? wildcards into an anchored regular expression.
+func globToRegex(glob string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, ch := range glob {
+		switch ch {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
@@ -0,0 +1,166 @@
+package filters
+
+import (
+	"testing"
+	"time"
+
+	"refleks/internal/models"
+)
+
+func TestHidden(t *testing.T) {
+	type check struct {
+		run    Run
+		hidden bool
+	}
+	challenge := func(name string, sec float64) Run {
+		return Run{Scenario: name, Mode: "Challenge", Duration: time.Duration(sec * float64(time.Second))}
+	}
+	cases := []struct {
+		name   string
+		rules  []models.ScenarioFilter
+		hidden []string
+		checks []check
+	}{
+		{
+			name: "empty rule set",
+			checks: []check{
+				{challenge("Air Tracking 180", 60), false},
+				{Run{Scenario: "anything"}, false},
+			},
+		},
+		{
+			name:   "hidden names ignore case",
+			hidden: []string{"  air tracking 180 "},
+			checks: []check{
+				{challenge("Air Tracking 180", 60), true},
+				{challenge("Air Tracking 90", 60), false},
+			},
+		},
+		{
+			name:  "glob is anchored and case-insensitive",
+			rules: []models.ScenarioFilter{{Pattern: "air*"}},
+			checks: []check{
+				{challenge("Air Tracking 180", 60), true},
+				{challenge("Smooth Air", 60), false},
+			},
+		},
+		{
+			name:  "glob ? matches one character and escapes the rest",
+			rules: []models.ScenarioFilter{{Pattern: "1w?ts (reload)"}},
+			checks: []check{
+				{challenge("1w4ts (Reload)", 60), true},
+				{challenge("1w44ts (reload)", 60), false},
+				{challenge("1w4ts reload", 60), false},
+			},
+		},
+		{
+			name:  "regex is unanchored",
+			rules: []models.ScenarioFilter{{Pattern: `strafe(s)?$`, Regex: true}},
+			checks: []check{
+				{challenge("Close Long Strafes", 60), true},
+				{challenge("Strafes Invincible", 60), false},
+			},
+		},
+		{
+			name:  "include hides everything else",
+			rules: []models.ScenarioFilter{{Action: "Include", Pattern: "VT *"}},
+			checks: []check{
+				{challenge("VT Pasu Intermediate S5", 60), false},
+				{challenge("Air Tracking 180", 60), true},
+			},
+		},
+		{
+			name: "exclude wins over include",
+			rules: []models.ScenarioFilter{
+				{Action: ActionInclude, Pattern: "VT *"},
+				{Action: ActionExclude, Pattern: "* S4"},
+			},
+			checks: []check{
+				{challenge("VT Pasu Intermediate S5", 60), false},
+				{challenge("VT Pasu Intermediate S4", 60), true},
+			},
+		},
+		{
+			name:  "mode narrows a rule",
+			rules: []models.ScenarioFilter{{Mode: "freeplay"}},
+			checks: []check{
+				{Run{Scenario: "Air", Mode: "Freeplay"}, true},
+				{Run{Scenario: "Air", Mode: "Challenge"}, false},
+			},
+		},
+		{
+			name:  "exclude minDuration hides only shorter runs",
+			rules: []models.ScenarioFilter{{MinDurationSeconds: 30}},
+			checks: []check{
+				{challenge("Air", 29.999), true},
+				{challenge("Air", 30), false},
+				{challenge("Air", 60), false},
+			},
+		},
+		{
+			name:  "include minDuration is a floor",
+			rules: []models.ScenarioFilter{{Action: ActionInclude, Pattern: "*", MinDurationSeconds: 30}},
+			checks: []check{
+				{challenge("Air", 29.999), true},
+				{challenge("Air", 30), false},
+			},
+		},
+	}
+	for _, c := range cases {
+		set, err := Compile(c.rules, c.hidden)
+		if err != nil {
+			t.Fatalf("%s: compile: %v", c.name, err)
+		}
+		for _, ch := range c.checks {
+			if got := set.Hidden(ch.run); got != ch.hidden {
+				t.Errorf("%s: Hidden(%q %s %v) = %v, want %v", c.name, ch.run.Scenario, ch.run.Mode, ch.run.Duration, got, ch.hidden)
+			}
+		}
+	}
+
+	var none *Set
+	if none.Hidden(challenge("Air", 1)) {
+		t.Error("a nil set should hide nothing")
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, r := range []models.ScenarioFilter{
+		{Action: "hide", Pattern: "Air"},
+		{Pattern: "(unclosed", Regex: true},
+	} {
+		if _, err := Compile([]models.ScenarioFilter{r}, nil); err == nil {
+			t.Errorf("Compile(%+v) should fail", r)
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	compile := func(hidden []string, rules ...models.ScenarioFilter) *Set {
+		t.Helper()
+		s, err := Compile(rules, hidden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	air := models.ScenarioFilter{Pattern: "Air*", Mode: "Challenge"}
+	base := compile([]string{"Tile Frenzy"}, air)
+	if !base.Equal(compile([]string{" tile frenzy "}, air)) {
+		t.Error("sets compiled from the same rules should be equal")
+	}
+	if !(*Set)(nil).Equal(compile(nil)) {
+		t.Error("a nil set should equal an empty one")
+	}
+	for name, other := range map[string]*Set{
+		"hidden":   compile(nil, air),
+		"pattern":  compile([]string{"Tile Frenzy"}, models.ScenarioFilter{Pattern: "Air", Mode: "Challenge"}),
+		"action":   compile([]string{"Tile Frenzy"}, models.ScenarioFilter{Action: ActionInclude, Pattern: "Air*", Mode: "Challenge"}),
+		"duration": compile([]string{"Tile Frenzy"}, models.ScenarioFilter{Pattern: "Air*", Mode: "Challenge", MinDurationSeconds: 30}),
+		"nil":      nil,
+	} {
+		if base.Equal(other) {
+			t.Errorf("sets differing in %s should not be equal", name)
+		}
+	}
+}
@@ -0,0 +1,339 @@
+package forecast
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"refleks/internal/history"
+	"refleks/internal/models"
+)
+
+const (
+	day    = 24 * time.Hour
+	minute = 1.0 / (24 * 60) // one minute in days
+
+	minRuns           = 4
+	halfLifeRuns      = 6.0
+	halfLifeDays      = 21.0
+	maxRunsReasonable = 500
+)
+
+type point struct {
+	t       time.Time
+	score   float64
+	session int
+}
+
+// collect picks the scenario's runs oldest first and tags each with its session.
+// Sessions are grouped from all runs, so other scenarios played in between keep a
+// session together the same way the session list does.
+func collect(scenario string, runs []history.Run, gap time.Duration) []point {
+	var pts []point
+	for sid, sess := range history.GroupSessions(runs, gap) {
+		for _, r := range sess {
+			if r.Scenario != scenario || math.IsNaN(r.Score) || math.IsInf(r.Score, 0) {
+				continue
+			}
+			pts = append(pts, point{t: r.End, score: r.Score, session: sid})
+		}
+	}
+	sort.SliceStable(pts, func(i, j int) bool { return pts[i].t.Before(pts[j].t) })
+	return pts
+}
+
+// Predict forecasts the next personal best of a scenario. runs are all visible runs,
+// not just the scenario's, so sessions are grouped with gap exactly as elsewhere. Score
+// gains between adjacent runs of the same session are modelled as a function of
+// the pause between them; the pause minimizing total time to the next PB gives the
+// expected number of runs.
+func Predict(scenario string, runs []history.Run, gap time.Duration, now time.Time) models.HighscorePrediction {
+	hist := collect(scenario, runs, gap)
+	n := len(hist)
+	out := models.HighscorePrediction{Scenario: scenario, Confidence: "low", Sample: n}
+	if n < minRuns {
+		out.Reason = "Need at least 4 runs"
+		return out
+	}
+	ys := make([]float64, n)
+	best := math.Inf(-1)
+	for i, h := range hist {
+		ys[i] = h.score
+		best = math.Max(best, h.score)
+	}
+	last := hist[n-1]
+	out.Best = best
+	out.LastScore = last.score
+	out.LastPlayedDays = now.Sub(last.t).Hours() / 24
+
+	// Diagnostics only: recency-weighted slopes per run and per day.
+	idx := make([]float64, n)
+	wsRuns := make([]float64, n)
+	for i := range hist {
+		idx[i] = float64(i)
+		wsRuns[i] = math.Pow(0.5, float64(n-1-i)/halfLifeRuns)
+	}
+	_, out.SlopePerRun, _ = weightedLinReg(idx, ys, wsRuns)
+	const minWeight = 0.04
+	var xs2, ys2, ws2 []float64
+	for _, h := range hist {
+		w := math.Pow(0.5, now.Sub(h.t).Hours()/24/halfLifeDays)
+		if n > 40 && w < minWeight {
+			continue
+		}
+		xs2 = append(xs2, h.t.Sub(hist[0].t).Hours()/24)
+		ys2 = append(ys2, h.score)
+		ws2 = append(ws2, w)
+	}
+	_, out.SlopePerDay, _ = weightedLinReg(xs2, ys2, ws2)
+
+	// Score deltas between adjacent runs, strictly within the same session.
+	var dtDays, deltas, pairWeights []float64
+	for i := 1; i < n; i++ {
+		if hist[i].session != hist[i-1].session {
+			continue
+		}
+		d := hist[i].t.Sub(hist[i-1].t).Hours() / 24
+		dtDays = append(dtDays, math.Max(minute, d))
+		deltas = append(deltas, ys[i]-ys[i-1])
+		pairWeights = append(pairWeights, math.Pow(0.5, float64(n-1-i)/halfLifeRuns))
+	}
+	havePairs := len(dtDays) >= 2
+	// Clip outlier deltas to 1.5 IQR.
+	if len(deltas) >= 6 {
+		q1, q3 := quantile(deltas, 0.25), quantile(deltas, 0.75)
+		iqr := math.Max(1, q3-q1)
+		for i := range deltas {
+			deltas[i] = clamp(deltas[i], q1-1.5*iqr, q3+1.5*iqr)
+		}
+	}
+	// Positive delta stats drive the fallback and confidence.
+	var meanPos, varPos float64
+	for _, d := range deltas {
+		meanPos += math.Max(0, d)
+	}
+	meanPos /= math.Max(1, float64(len(deltas)))
+	for _, d := range deltas {
+		p := math.Max(0, d) - meanPos
+		varPos += p * p
+	}
+	varPos /= math.Max(1, float64(len(deltas)))
+	stdPos := math.Sqrt(math.Max(0, varPos))
+
+	f := fit{tau: 5 * minute}
+	if havePairs {
+		f = fitDeltaVsPause(dtDays, deltas, pairWeights)
+	}
+	target := best + math.Max(1, math.Round(best*0.003))
+	deficit := target - last.score
+	sizeFactor := math.Min(1, float64(len(dtDays))/24)
+	stability := 0.0
+	if meanPos > 1e-6 {
+		stability = clamp(1-math.Min(2, stdPos/math.Max(1, meanPos)), 0, 1)
+	}
+	recencyPenalty := clamp(out.LastPlayedDays/21, 0, 1)
+
+	// Already within a hair of the best: expect a PB within a run or two.
+	epsImprovement := math.Max(1, math.Round(best*0.0002))
+	if best-last.score <= epsImprovement {
+		pauseH := math.Max(1, math.Round(quantile(dtDays, 0.25)*24))
+		eta := now.Add(time.Duration(math.Max(float64(day)/4, pauseH*float64(time.Hour))))
+		score := clamp(0.4*f.r2+0.6*sizeFactor, 0, 1)
+		out.PredictedScore = target
+		out.RunsExpected, out.RunsLo, out.RunsHi = 1, 1, 2
+		out.ETA = eta.Format(time.RFC3339)
+		out.OptPauseHours = pauseH
+		setConfidence(&out, score)
+		return out
+	}
+
+	// Search pauses within the range observed inside sessions.
+	gapDays := gap.Hours() / 24
+	dtMin := 2 * minute
+	dtMax := math.Min(gapDays, 15*minute)
+	if havePairs {
+		dtMin = math.Max(minute, quantile(dtDays, 0.1)*0.7)
+		dtMax = math.Max(dtMin*1.2, math.Min(gapDays, quantile(dtDays, 0.9)*1.4))
+	}
+	dtStep := math.Max(0.5*minute, (dtMax-dtMin)/60)
+	opt, ok := optimalPause(deficit, f, dtMin, dtMax, dtStep)
+	if !ok || opt.runs > maxRunsReasonable || opt.delta < epsImprovement {
+		// Fallback: mean non-negative improvement per run at the median observed pause.
+		medDt := 5 * minute
+		if havePairs {
+			if q := quantile(dtDays, 0.5); q > 0 {
+				medDt = q
+			}
+		}
+		spr := math.Max(1e-6, meanPos)
+		runsRaw := deficit / spr
+		if math.IsInf(runsRaw, 0) || math.IsNaN(runsRaw) || runsRaw > maxRunsReasonable || spr < epsImprovement*0.25 {
+			out.Reason = "No upward trend detected yet"
+			return out
+		}
+		need := int(math.Ceil(runsRaw))
+		score := clamp(0.15+0.55*(0.6*sizeFactor+0.4*stability)-0.15*recencyPenalty, 0, 1)
+		widen := 0.28 + 0.32*(1-score) + 0.12*(1-stability)
+		out.PredictedScore = target
+		setRuns(&out, need, widen)
+		out.ETA = now.Add(time.Duration(float64(max(1, need)) * medDt * float64(day))).Format(time.RFC3339)
+		out.OptPauseHours = math.Round(medDt * 24)
+		out.Reason = "Using robust recent trend"
+		setConfidence(&out, score)
+		return out
+	}
+
+	need := max(1, int(math.Ceil(opt.runs)))
+	score := clamp(0.15+0.55*(0.7*f.r2+0.3*sizeFactor)+0.3*stability-0.2*recencyPenalty, 0, 1)
+	widen := 0.22 + 0.30*(1-score) + 0.12*(1-stability)
+	out.PredictedScore = target
+	setRuns(&out, need, widen)
+	out.ETA = now.Add(time.Duration(float64(need) * opt.dtDays * float64(day))).Format(time.RFC3339)
+	// Never recommend pausing past the session gap; keep at least a minute.
+	out.OptPauseHours = math.Max(1.0/60, math.Min(opt.dtDays*24, gap.Hours()))
+	setConfidence(&out, score)
+	return out
+}
+
+func setRuns(out *models.HighscorePrediction, runs int, widen float64) {
+	lo := max(1, int(math.Floor(float64(runs)*(1-widen))))
+	out.RunsExpected = runs
+	out.RunsLo = lo
+	out.RunsHi = max(lo+1, int(math.Ceil(float64(runs)*(1+widen))))
+}
+
+func setConfidence(out *models.HighscorePrediction, score float64) {
+	out.ConfidenceScore = score
+	switch {
+	case score > 0.6:
+		out.Confidence = "high"
+	case score > 0.3:
+		out.Confidence = "med"
+	default:
+		out.Confidence = "low"
+	}
+}
+
+// fit models the score delta per run as a*(1-exp(-dt/tau)) + b for a pause of dt days.
+type fit struct {
+	a, b, tau, r2 float64
+}
+
+func (f fit) deltaAt(dtDays float64) float64 {
+	x := 1 - math.Exp(-math.Max(0, dtDays)/math.Max(1e-6, f.tau))
+	return f.a*x + f.b
+}
+
+// fitDeltaVsPause grid-searches tau over the observed pause range and keeps the best R².
+func fitDeltaVsPause(dtDays, deltas, weights []float64) fit {
+	var safe []float64
+	for _, d := range dtDays {
+		if d > 0 {
+			safe = append(safe, d)
+		}
+	}
+	minDt, maxDt := 5*minute, 2.0/24
+	if len(safe) > 0 {
+		lowest := safe[0]
+		for _, d := range safe {
+			lowest = math.Min(lowest, d)
+		}
+		minDt = math.Max(minute, lowest)
+		maxDt = math.Max(minDt*5, quantile(safe, 0.9))
+	}
+	lo, hi := math.Log10(minDt/3), math.Log10(maxDt*3)
+	const steps = 25
+	best := fit{tau: math.Sqrt(minDt * maxDt)}
+	xs := make([]float64, len(dtDays))
+	for i := 0; i < steps; i++ {
+		tau := math.Pow(10, lo+(hi-lo)*float64(i)/(steps-1))
+		for j, d := range dtDays {
+			xs[j] = 1 - math.Exp(-math.Max(0, d)/tau)
+		}
+		a, b, r2 := weightedLinReg(xs, deltas, weights)
+		if r2 > best.r2 {
+			best = fit{a: a, b: b, tau: tau, r2: r2}
+		}
+	}
+	return best
+}
+
+type pause struct {
+	dtDays, runs, delta float64
+}
+
+// optimalPause minimizes total time (runs * pause) to cover deficit, where runs is
+// deficit divided by the expected gain at that pause.
+func optimalPause(deficit float64, f fit, dtMin, dtMax, dtStep float64) (pause, bool) {
+	best := pause{dtDays: 1, runs: math.Inf(1)}
+	for d := dtMin; d <= dtMax+1e-9; d += dtStep {
+		delta := math.Max(0, f.deltaAt(d))
+		if delta <= 1e-6 {
+			continue
+		}
+		runs := deficit / delta
+		if t := runs * d; !math.IsInf(t, 0) && !math.IsNaN(t) && t < best.runs*best.dtDays {
+			best = pause{dtDays: d, runs: runs, delta: delta}
+		}
+	}
+	return best, !math.IsInf(best.runs, 0)
+}
+
+// weightedLinReg fits y = a + b*x by weighted least squares and returns the weighted R².
+func weightedLinReg(xs, ys, ws []float64) (a, b, r2 float64) {
+	n := min(len(xs), len(ys), len(ws))
+	if n < 2 {
+		return 0, 0, 0
+	}
+	var sw, swx, swy, swxx, swxy float64
+	for i := 0; i < n; i++ {
+		w, x, y := ws[i], xs[i], ys[i]
+		sw += w
+		swx += w * x
+		swy += w * y
+		swxx += w * x * x
+		swxy += w * x * y
+	}
+	if den := sw*swxx - swx*swx; den != 0 {
+		b = (sw*swxy - swx*swy) / den
+	}
+	if sw != 0 {
+		a = (swy - b*swx) / sw
+	}
+	var mean float64
+	if sw != 0 {
+		mean = swy / sw
+	}
+	var ssRes, ssTot float64
+	for i := 0; i < n; i++ {
+		e := ys[i] - (a + b*xs[i])
+		d := ys[i] - mean
+		ssRes += ws[i] * e * e
+		ssTot += ws[i] * d * d
+	}
+	if ssTot > 0 {
+		r2 = 1 - ssRes/ssTot
+	}
+	return a, b, r2
+}
+
+// quantile returns the q-quantile of values with linear interpolation.
+func quantile(values []float64, q float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	s := append([]float64(nil), values...)
+	sort.Float64s(s)
+	pos := clamp(q, 0, 1) * float64(len(s)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	if lo == hi {
+		return s[lo]
+	}
+	f := pos - float64(lo)
+	return s[lo]*(1-f) + s[hi]*f
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
@@ -0,0 +1,134 @@
+package forecast
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"refleks/internal/history"
+	"refleks/internal/parser"
+	"refleks/internal/util"
+)
+
+const testdataStats = "../../testdata/stats"
+
+// loadRuns parses every testdata stats file of a scenario into history runs.
+func loadRuns(t *testing.T, scenario string) []history.Run {
+	t.Helper()
+	entries, err := os.ReadDir(testdataStats)
+	if err != nil {
+		t.Fatalf("read testdata: %v", err)
+	}
+	var runs []history.Run
+	for _, e := range entries {
+		info, err := parser.ParseFilename(e.Name())
+		if err != nil || info.ScenarioName != scenario {
+			continue
+		}
+		_, stats, err := parser.ParseStatsFile(filepath.Join(testdataStats, e.Name()))
+		if err != nil {
+			t.Fatalf("parse %s: %v", e.Name(), err)
+		}
+		end := info.DatePlayed
+		runs = append(runs, history.Run{ID: e.Name(), Scenario: scenario, Start: end.Add(-time.Minute), End: end, Score: util.ToFloat(stats["Score"]), Stats: stats})
+	}
+	return runs
+}
+
+func TestPredictTestdata(t *testing.T) {
+	tests := []struct {
+		scenario   string
+		wantSample int
+		wantBest   float64
+		wantReason string
+		// wantRuns is false when no forecast can be made.
+		wantRuns bool
+	}{
+		{"VT 1w3ts Intermediate S5", 20, 1300.113037, "", true},
+		{"VT Ground Intermediate S5", 18, 3384, "Using robust recent trend", true},
+		{"VT ww5t Intermediate S5", 9, 1420, "", true},
+		{"✦ ADAD Trance", 2, 0, "Need at least 4 runs", false},
+		{"✦ Dynamic Micro Hell", 2, 0, "Need at least 4 runs", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			runs := loadRuns(t, tt.scenario)
+			now := runs[len(runs)-1].End.Add(time.Hour)
+			got := Predict(tt.scenario, runs, 15*time.Minute, now)
+			if got.Sample != tt.wantSample || got.Best != tt.wantBest || got.Reason != tt.wantReason {
+				t.Fatalf("sample/best/reason = %d/%v/%q, want %d/%v/%q", got.Sample, got.Best, got.Reason, tt.wantSample, tt.wantBest, tt.wantReason)
+			}
+			if (got.RunsExpected > 0) != tt.wantRuns {
+				t.Fatalf("runsExpected = %d, want forecast %v", got.RunsExpected, tt.wantRuns)
+			}
+			if !tt.wantRuns {
+				return
+			}
+			if got.PredictedScore <= got.Best {
+				t.Errorf("predicted score %v does not beat best %v", got.PredictedScore, got.Best)
+			}
+			if got.RunsLo > got.RunsExpected || got.RunsHi <= got.RunsLo || got.RunsHi < got.RunsExpected {
+				t.Errorf("invalid run interval %d <= %d <= %d", got.RunsLo, got.RunsExpected, got.RunsHi)
+			}
+			if got.ETA == "" || got.Confidence == "" {
+				t.Errorf("missing eta/confidence: %+v", got)
+			}
+			// Input order must not matter.
+			rev := append([]history.Run(nil), runs...)
+			for i, j := 0, len(rev)-1; i < j; i, j = i+1, j-1 {
+				rev[i], rev[j] = rev[j], rev[i]
+			}
+			if again := Predict(tt.scenario, rev, 15*time.Minute, now); again != got {
+				t.Errorf("prediction depends on input order: %+v vs %+v", again, got)
+			}
+		})
+	}
+}
+
+func TestPredictSynthetic(t *testing.T) {
+	base := time.Date(2025, 10, 2, 18, 0, 0, 0, time.UTC)
+	series := func(scores ...float64) []history.Run {
+		runs := make([]history.Run, len(scores))
+		for i, s := range scores {
+			end := base.Add(time.Duration(i) * 3 * time.Minute)
+			runs[i] = history.Run{ID: string(rune('a' + i)), Scenario: "synthetic", Start: end.Add(-time.Minute), End: end, Score: s}
+		}
+		return runs
+	}
+	tests := []struct {
+		name       string
+		runs       []history.Run
+		wantReason string
+		wantRuns   int
+	}{
+		{"flat", series(500, 500, 500, 500, 500, 400), "No upward trend detected yet", 0},
+		{"at best", series(400, 420, 450, 480, 500, 500), "", 1},
+		{"too few", series(400, 500, 600), "Need at least 4 runs", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Predict("synthetic", tt.runs, 15*time.Minute, base.Add(time.Hour))
+			if got.Reason != tt.wantReason || got.RunsExpected != tt.wantRuns {
+				t.Fatalf("reason/runs = %q/%d, want %q/%d", got.Reason, got.RunsExpected, tt.wantReason, tt.wantRuns)
+			}
+		})
+	}
+}
+
+func TestCollectSharesSessionsAcrossScenarios(t *testing.T) {
+	base := time.Date(2025, 10, 2, 18, 0, 0, 0, time.UTC)
+	run := func(id, scenario string, min int) history.Run {
+		end := base.Add(time.Duration(min) * time.Minute)
+		return history.Run{ID: id, Scenario: scenario, Start: end.Add(-time.Minute), End: end, Score: 100}
+	}
+	// A, then half an hour of B, then A again: one session, as the session list shows.
+	runs := []history.Run{run("1", "A", 0), run("2", "B", 10), run("3", "B", 20), run("4", "A", 30)}
+	pts := collect("A", runs, 15*time.Minute)
+	if len(pts) != 2 || pts[0].session != pts[1].session {
+		t.Fatalf("expected both A runs in one session, got %+v", pts)
+	}
+	if pts := collect("A", []history.Run{runs[0], runs[3]}, 15*time.Minute); pts[0].session == pts[1].session {
+		t.Errorf("without B in between the A runs should be separate sessions, got %+v", pts)
+	}
+}
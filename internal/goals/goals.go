@@ -0,0 +1,345 @@
+package goals
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"refleks/internal/constants"
+	"refleks/internal/history"
+	"refleks/internal/models"
+	appsettings "refleks/internal/settings"
+	"refleks/internal/util"
+)
+
+// EventName is emitted by the watcher when a run meets a goal.
+const EventName = "GoalAchieved"
+
+// Metrics accepted in models.Goal.Metric.
+const (
+	MetricScore    = "score"
+	MetricAccuracy = "accuracy"
+	MetricTTK      = "ttk"
+)
+
+const (
+	// trendDays is how far back the daily-best trend used for the ETA reaches.
+	trendDays = 30
+	// minTrendDays is the fewest played days needed for an ETA.
+	minTrendDays = 3
+)
+
+// Value returns a run's value for metric, or false when the run has none.
+func Value(metric string, r history.Run) (float64, bool) {
+	var v float64
+	switch metric {
+	case MetricScore:
+		v = r.Score
+	case MetricAccuracy:
+		v = util.ToFloat(r.Stats["Accuracy"])
+	case MetricTTK:
+		v = util.ToFloat(r.Stats["Real Avg TTK"])
+		if v <= 0 {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	return v, !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// better reports whether a beats b for metric; TTK is the only lower-is-better metric.
+func better(metric string, a, b float64) bool {
+	if metric == MetricTTK {
+		return a < b
+	}
+	return a > b
+}
+
+// meets reports whether v reaches the goal's target.
+func meets(g models.Goal, v float64) bool {
+	return v == g.Target || better(g.Metric, v, g.Target)
+}
+
+// Validate normalizes a goal and reports invalid fields.
+func Validate(g models.Goal) (models.Goal, error) {
+	g.Scenario = strings.TrimSpace(g.Scenario)
+	g.Metric = strings.ToLower(strings.TrimSpace(g.Metric))
+	if g.Scenario == "" {
+		return g, errors.New("goal needs a scenario")
+	}
+	switch g.Metric {
+	case MetricScore, MetricAccuracy, MetricTTK:
+	default:
+		return g, fmt.Errorf("unknown goal metric %q", g.Metric)
+	}
+	if !(g.Target > 0) {
+		return g, errors.New("goal target must be positive")
+	}
+	if g.Metric == MetricAccuracy && g.Target > 1 {
+		return g, errors.New("accuracy target must be between 0 and 1")
+	}
+	if g.Deadline != "" {
+		// A bare date means the end of that day.
+		if d, err := time.ParseInLocation("2006-01-02", g.Deadline, time.Local); err == nil {
+			g.Deadline = d.AddDate(0, 0, 1).Add(-time.Second).Format(time.RFC3339)
+		} else if _, err := time.Parse(time.RFC3339, g.Deadline); err != nil {
+			return g, fmt.Errorf("invalid deadline: %w", err)
+		}
+	}
+	return g, nil
+}
+
+// Evaluate computes a goal's status from the scenario's runs.
+func Evaluate(g models.Goal, runs []history.Run, now time.Time) models.GoalStatus {
+	st := models.GoalStatus{Goal: g, Achieved: g.AchievedAt != ""}
+	first := true
+	daily := make(map[string]float64)
+	since := now.AddDate(0, 0, -trendDays)
+	for _, r := range runs {
+		if r.Scenario != g.Scenario {
+			continue
+		}
+		v, ok := Value(g.Metric, r)
+		if !ok {
+			continue
+		}
+		st.Runs++
+		if first || better(g.Metric, v, st.Best) {
+			st.Best, first = v, false
+		}
+		if r.End.Before(since) {
+			continue
+		}
+		day := r.End.Local().Format("2006-01-02")
+		if b, ok := daily[day]; !ok || better(g.Metric, v, b) {
+			daily[day] = v
+		}
+	}
+	if st.Runs > 0 {
+		if g.Metric == MetricTTK {
+			st.Progress = math.Min(1, g.Target/st.Best)
+		} else {
+			st.Progress = math.Min(1, st.Best/g.Target)
+		}
+	}
+	var deadline time.Time
+	if g.Deadline != "" {
+		deadline, _ = time.Parse(time.RFC3339, g.Deadline)
+	}
+	if st.Achieved {
+		st.OnTrack = true
+		return st
+	}
+	st.Overdue = !deadline.IsZero() && now.After(deadline)
+	if eta, ok := projectETA(g, daily, now); ok {
+		st.ETA = eta.Format(time.RFC3339)
+		st.OnTrack = deadline.IsZero() || !eta.After(deadline)
+	}
+	return st
+}
+
+// projectETA fits a line through the daily bests and returns when it reaches the
+// target, provided the trend moves towards it.
+func projectETA(g models.Goal, daily map[string]float64, now time.Time) (time.Time, bool) {
+	if len(daily) < minTrendDays {
+		return time.Time{}, false
+	}
+	var xs, ys []float64
+	for day, v := range daily {
+		t, err := time.ParseInLocation("2006-01-02", day, time.Local)
+		if err != nil {
+			continue
+		}
+		xs = append(xs, t.Sub(now).Hours()/24)
+		ys = append(ys, v)
+	}
+	n := float64(len(xs))
+	var sx, sy, sxy, sxx float64
+	for i := range xs {
+		sx += xs[i]
+		sy += ys[i]
+		sxy += xs[i] * ys[i]
+		sxx += xs[i] * xs[i]
+	}
+	den := n*sxx - sx*sx
+	if den == 0 {
+		return time.Time{}, false
+	}
+	b := (n*sxy - sx*sy) / den
+	a := (sy - b*sx) / n
+	if !better(g.Metric, b, 0) {
+		return time.Time{}, false
+	}
+	// a is the trend value today; days until the line crosses the target.
+	days := math.Max(0, (g.Target-a)/b)
+	if days > 3650 {
+		return time.Time{}, false
+	}
+	return now.Add(time.Duration(days * 24 * float64(time.Hour))), true
+}
+
+// Store keeps goals in a JSON file in the app config directory.
+// It is safe for concurrent use.
+type Store struct {
+	mu    sync.Mutex
+	path  string
+	goals []models.Goal
+	// loadErr is why goals.json could not be loaded. Changes are refused while it is
+	// set so the file is never replaced by an empty list.
+	loadErr error
+}
+
+// Open loads goals from goals.json in the app config directory; a missing file just
+// means no goals yet. If the directory or file can't be read, the error is returned
+// with an empty store that refuses changes and leaves the file untouched.
+func Open() (*Store, error) {
+	s := &Store{}
+	base, err := appsettings.ConfigBaseDir()
+	if err != nil {
+		s.loadErr = fmt.Errorf("goals unavailable: %w", err)
+		return s, s.loadErr
+	}
+	s.path = filepath.Join(base, constants.GoalsFileName)
+	if err := util.LoadJSON(s.path, &s.goals); err != nil {
+		s.goals = nil
+		s.loadErr = fmt.Errorf("%s could not be read, fix or remove it to edit goals: %w", s.path, err)
+		return s, s.loadErr
+	}
+	return s, nil
+}
+
+// NewMemory returns a store without persistence.
+func NewMemory() *Store {
+	return &Store{}
+}
+
+// Err reports why the saved goals could not be loaded, or nil.
+func (s *Store) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loadErr
+}
+
+// List returns every goal, oldest first.
+func (s *Store) List() []models.Goal {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]models.Goal(nil), s.goals...)
+}
+
+// Get returns the goal with the given ID.
+func (s *Store) Get(id string) (models.Goal, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, g := range s.goals {
+		if g.ID == id {
+			return g, true
+		}
+	}
+	return models.Goal{}, false
+}
+
+// Add validates and stores a new goal, assigning its ID and creation time.
+func (s *Store) Add(g models.Goal, now time.Time) (models.Goal, error) {
+	g, err := Validate(g)
+	if err != nil {
+		return g, err
+	}
+	g.ID = strconv.FormatInt(now.UnixNano(), 36)
+	g.CreatedAt = now.Format(time.RFC3339)
+	g.AchievedAt, g.AchievedRunID = "", ""
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loadErr != nil {
+		return models.Goal{}, s.loadErr
+	}
+	s.goals = append(s.goals, g)
+	return g, s.saveLocked()
+}
+
+// Update replaces the editable fields of an existing goal. Changing the scenario,
+// metric or target resets its achievement.
+func (s *Store) Update(g models.Goal) error {
+	g, err := Validate(g)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loadErr != nil {
+		return s.loadErr
+	}
+	for i, old := range s.goals {
+		if old.ID != g.ID {
+			continue
+		}
+		g.CreatedAt = old.CreatedAt
+		g.AchievedAt, g.AchievedRunID = old.AchievedAt, old.AchievedRunID
+		if g.Scenario != old.Scenario || g.Metric != old.Metric || g.Target != old.Target {
+			g.AchievedAt, g.AchievedRunID = "", ""
+		}
+		s.goals[i] = g
+		return s.saveLocked()
+	}
+	return fmt.Errorf("goal %q not found", g.ID)
+}
+
+// Delete removes a goal.
+func (s *Store) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loadErr != nil {
+		return s.loadErr
+	}
+	for i, g := range s.goals {
+		if g.ID == id {
+			s.goals = append(s.goals[:i], s.goals[i+1:]...)
+			return s.saveLocked()
+		}
+	}
+	return fmt.Errorf("goal %q not found", id)
+}
+
+// Check marks every open goal met by runs played after the goal was created as
+// achieved, returning the newly achieved goals. runs should be in play order so the
+// first qualifying run is recorded.
+func (s *Store) Check(runs []history.Run) ([]models.Goal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var done []models.Goal
+	for i := range s.goals {
+		g := &s.goals[i]
+		if g.AchievedAt != "" {
+			continue
+		}
+		created, _ := time.Parse(time.RFC3339, g.CreatedAt)
+		for _, r := range runs {
+			if r.Scenario != g.Scenario || r.End.Before(created) {
+				continue
+			}
+			if v, ok := Value(g.Metric, r); ok && meets(*g, v) {
+				g.AchievedAt = r.End.Format(time.RFC3339)
+				g.AchievedRunID = r.ID
+				done = append(done, *g)
+				break
+			}
+		}
+	}
+	if len(done) == 0 {
+		return nil, nil
+	}
+	return done, s.saveLocked()
+}
+
+func (s *Store) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	return util.SaveJSON(s.path, s.goals)
+}
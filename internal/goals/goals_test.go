@@ -0,0 +1,108 @@
+package goals
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"refleks/internal/constants"
+	"refleks/internal/history"
+	"refleks/internal/models"
+	appsettings "refleks/internal/settings"
+)
+
+const scenario = "VT 1w3ts Intermediate S5"
+
+// dailyRuns returns one run per day ending at now, with the best score rising 10 a day.
+func dailyRuns(now time.Time, days int) []history.Run {
+	var runs []history.Run
+	for d := days - 1; d >= 0; d-- {
+		end := now.AddDate(0, 0, -d)
+		runs = append(runs, history.Run{
+			ID:       fmt.Sprint(d),
+			Scenario: scenario,
+			Start:    end.Add(-time.Minute),
+			End:      end,
+			Score:    1200 - float64(d*10),
+			Stats:    map[string]any{"Accuracy": 0.8, "Real Avg TTK": 0.5},
+		})
+	}
+	return runs
+}
+
+func TestEvaluateETA(t *testing.T) {
+	now := time.Date(2025, 10, 10, 12, 0, 0, 0, time.Local)
+	runs := dailyRuns(now, 10)
+	g := models.Goal{Scenario: scenario, Metric: MetricScore, Target: 1300, Deadline: now.AddDate(0, 0, 5).Format(time.RFC3339)}
+	st := Evaluate(g, runs, now)
+	if st.Best != 1200 || st.Runs != 10 || st.Achieved {
+		t.Fatalf("unexpected status %+v", st)
+	}
+	eta, err := time.Parse(time.RFC3339, st.ETA)
+	if err != nil {
+		t.Fatalf("expected an ETA, got %q", st.ETA)
+	}
+	if days := eta.Sub(now).Hours() / 24; days < 9 || days > 11 {
+		t.Errorf("ETA in %.1f days, want about 10", days)
+	}
+	if st.OnTrack {
+		t.Error("an ETA after the deadline should not be on track")
+	}
+
+	ttk := Evaluate(models.Goal{Scenario: scenario, Metric: MetricTTK, Target: 0.4}, runs, now)
+	if ttk.Progress != 0.8 || ttk.ETA != "" {
+		t.Errorf("flat TTK should have progress 0.8 and no ETA, got %+v", ttk)
+	}
+}
+
+func TestStoreCheck(t *testing.T) {
+	now := time.Date(2025, 10, 10, 12, 0, 0, 0, time.Local)
+	s := NewMemory()
+	if _, err := s.Add(models.Goal{Scenario: scenario, Metric: "bogus", Target: 1}, now); err == nil {
+		t.Fatal("expected an invalid metric to be rejected")
+	}
+	g, err := s.Add(models.Goal{Scenario: scenario, Metric: MetricScore, Target: 1150}, now.AddDate(0, 0, -3).Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The run from three days ago (1170) predates the goal; two days ago (1180) meets it.
+	done, err := s.Check(dailyRuns(now, 5))
+	if err != nil || len(done) != 1 || done[0].AchievedRunID != "2" {
+		t.Fatalf("expected the goal to be met by run 2, got %+v (%v)", done, err)
+	}
+	if again, _ := s.Check(dailyRuns(now, 5)); len(again) != 0 {
+		t.Errorf("an achieved goal should not be reported twice")
+	}
+	g.Target = 1250
+	if err := s.Update(g); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.Get(g.ID); got.AchievedAt != "" {
+		t.Errorf("raising the target should reset the achievement: %+v", got)
+	}
+}
+
+func TestOpenUnreadableFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	base, err := appsettings.ConfigBaseDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(base, constants.GoalsFileName)
+	const broken = `[{"id": "a", "scenario":`
+	if err := os.WriteFile(path, []byte(broken), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Open()
+	if err == nil || s.Err() == nil {
+		t.Fatal("expected the parse error to be reported")
+	}
+	if _, err := s.Add(models.Goal{Scenario: scenario, Metric: MetricScore, Target: 1}, time.Now()); err == nil {
+		t.Error("adding a goal should fail while goals.json is unreadable")
+	}
+	if b, _ := os.ReadFile(path); string(b) != broken {
+		t.Errorf("goals.json was rewritten: %q", b)
+	}
+}
@@ -0,0 +1,32 @@
+package history
+
+import (
+	"time"
+
+	"refleks/internal/sessions"
+)
+
+// GroupSessions splits runs into sessions using the same rules as the live session
+// tracker. Sessions are returned oldest first, each with its runs oldest first.
+func GroupSessions(runs []Run, gap time.Duration) [][]Run {
+	byID := make(map[string]Run, len(runs))
+	in := make([]sessions.Run, 0, len(runs))
+	for _, r := range runs {
+		byID[r.ID] = r
+		in = append(in, sessions.Run{ID: r.ID, Scenario: r.Scenario, Start: r.Start, End: r.End, Score: r.Score})
+	}
+	grouped := sessions.Group(in, gap)
+	out := make([][]Run, 0, len(grouped))
+	for i := len(grouped) - 1; i >= 0; i-- {
+		sess := make([]Run, 0, len(grouped[i].RunIDs))
+		for _, id := range grouped[i].RunIDs {
+			if r, ok := byID[id]; ok {
+				sess = append(sess, r)
+			}
+		}
+		if len(sess) > 0 {
+			out = append(out, sess)
+		}
+	}
+	return out
+}
@@ -0,0 +1,301 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"refleks/internal/constants"
+	appsettings "refleks/internal/settings"
+)
+
+// schemaVersion is bumped whenever derived fields in Run.Stats change, which
+// discards the on-disk cache so every run is re-parsed once.
+const schemaVersion = 2
+
+// Run is a compact summary of one scenario run: the key-value stats block plus
+// derived fields, without kill events or mouse traces. Runs are immutable once
+// parsed because Kovaak's never rewrites a stats file.
+type Run struct {
+	// ID is the stats file name, unique per run.
+	ID       string         `json:"id"`
+	Scenario string         `json:"scenario"`
+	Mode     string         `json:"mode,omitempty"`
+	Start    time.Time      `json:"start"`
+	End      time.Time      `json:"end"`
+	Score    float64        `json:"score"`
+	Stats    map[string]any `json:"stats"`
+	// Hidden mirrors the current scenario filter rules and anomaly exclusion; it is
+	// never persisted.
+	Hidden bool `json:"-"`
+	// Anomalies holds the run's anomaly reason codes; recomputed, never persisted.
+	Anomalies []string `json:"-"`
+}
+
+type header struct {
+	Version int `json:"version"`
+}
+
+// Store holds every known run and persists new ones to an append-only JSON lines
+// file, so full history is available without re-parsing thousands of stats files.
+// It is safe for concurrent use.
+type Store struct {
+	mu      sync.RWMutex
+	runs    []Run
+	byID    map[string]int
+	sorted  bool
+	path    string
+	pending []Run
+	// rewrite is set when the cache on disk is stale and must be replaced on Flush.
+	rewrite bool
+}
+
+// Open loads the run history cache from the app config directory. A cache from
+// another schema version is ignored and replaced on the next Flush; on a read error
+// the store keeps the runs loaded so far.
+func Open() (*Store, error) {
+	s := &Store{byID: make(map[string]int), sorted: true}
+	base, err := appsettings.ConfigBaseDir()
+	if err != nil {
+		return s, err
+	}
+	s.path = filepath.Join(base, constants.HistoryFileName)
+	return s, s.load()
+}
+
+// NewMemory returns a store without persistence.
+func NewMemory() *Store {
+	return &Store{byID: make(map[string]int), sorted: true}
+}
+
+func (s *Store) load() error {
+	f, err := os.Open(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	if !sc.Scan() {
+		return sc.Err()
+	}
+	var h header
+	if err := json.Unmarshal(sc.Bytes(), &h); err != nil || h.Version != schemaVersion {
+		s.rewrite = true
+		return nil
+	}
+	for sc.Scan() {
+		var r Run
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil || r.ID == "" {
+			// Skip a torn line rather than dropping the whole cache, and rewrite the
+			// file on the next flush so appends don't land on the torn bytes.
+			s.rewrite = true
+			continue
+		}
+		s.putLocked(r)
+	}
+	return sc.Err()
+}
+
+// putLocked inserts or replaces a run. Caller must hold s.mu for writing.
+func (s *Store) putLocked(r Run) {
+	if i, ok := s.byID[r.ID]; ok {
+		s.runs[i] = r
+		return
+	}
+	if n := len(s.runs); n > 0 && r.End.Before(s.runs[n-1].End) {
+		s.sorted = false
+	}
+	s.byID[r.ID] = len(s.runs)
+	s.runs = append(s.runs, r)
+}
+
+// sortLocked orders runs by end time. Caller must hold s.mu for writing.
+func (s *Store) sortLocked() {
+	if s.sorted {
+		return
+	}
+	sort.SliceStable(s.runs, func(i, j int) bool { return s.runs[i].End.Before(s.runs[j].End) })
+	for i, r := range s.runs {
+		s.byID[r.ID] = i
+	}
+	s.sorted = true
+}
+
+// Has reports whether a run with the given ID is known.
+func (s *Store) Has(id string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.byID[id]
+	return ok
+}
+
+// Get returns the run with the given ID.
+func (s *Store) Get(id string) (Run, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	i, ok := s.byID[id]
+	if !ok {
+		return Run{}, false
+	}
+	return s.runs[i], true
+}
+
+// Add inserts a run (or replaces one with the same ID) and queues it for persistence.
+func (s *Store) Add(r Run) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.putLocked(r)
+	s.pending = append(s.pending, r)
+}
+
+// SetHidden re-evaluates the hidden flag of every run.
+func (s *Store) SetHidden(hidden func(Run) bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.runs {
+		s.runs[i].Hidden = hidden(s.runs[i])
+	}
+}
+
+// SetAnomalies replaces the anomaly reason codes of every run.
+func (s *Store) SetAnomalies(codes func(Run) []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.runs {
+		s.runs[i].Anomalies = codes(s.runs[i])
+	}
+}
+
+// Len returns the number of known runs.
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.runs)
+}
+
+// All returns a copy of every run, oldest first.
+func (s *Store) All() []Run {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sortLocked()
+	return append([]Run(nil), s.runs...)
+}
+
+// Visible returns every run not hidden by filter rules or anomaly exclusion, oldest first.
+func (s *Store) Visible() []Run {
+	return s.Filter(func(r Run) bool { return !r.Hidden })
+}
+
+// Scenario returns the visible runs of one scenario, oldest first.
+func (s *Store) Scenario(name string) []Run {
+	return s.Filter(func(r Run) bool { return !r.Hidden && r.Scenario == name })
+}
+
+// Filter returns the runs accepted by keep, oldest first.
+func (s *Store) Filter(keep func(Run) bool) []Run {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sortLocked()
+	var out []Run
+	for _, r := range s.runs {
+		if keep(r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Scenarios returns the distinct names of scenarios with at least one visible run.
+func (s *Store) Scenarios() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	set := make(map[string]struct{})
+	for _, r := range s.runs {
+		if !r.Hidden {
+			set[r.Scenario] = struct{}{}
+		}
+	}
+	out := make([]string, 0, len(set))
+	for name := range set {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Flush persists runs added since the last flush. A stale cache is rewritten in full.
+func (s *Store) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.path == "" || (len(s.pending) == 0 && !s.rewrite) {
+		return nil
+	}
+	if s.rewrite {
+		if err := s.rewriteLocked(); err != nil {
+			return err
+		}
+		s.rewrite = false
+		s.pending = nil
+		return nil
+	}
+	_, statErr := os.Stat(s.path)
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	if errors.Is(statErr, os.ErrNotExist) {
+		if err := enc.Encode(header{Version: schemaVersion}); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	for _, r := range s.pending {
+		if err := enc.Encode(r); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	s.pending = nil
+	return f.Close()
+}
+
+// rewriteLocked replaces the cache file with the current runs. Caller must hold s.mu.
+func (s *Store) rewriteLocked() error {
+	tmp := s.path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	err = enc.Encode(header{Version: schemaVersion})
+	for i := 0; err == nil && i < len(s.runs); i++ {
+		err = enc.Encode(s.runs[i])
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
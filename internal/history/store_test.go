@@ -0,0 +1,133 @@
+package history
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"refleks/internal/constants"
+)
+
+// openTemp opens a store in a fresh config directory and returns the cache path.
+func openTemp(t *testing.T) (*Store, string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	s, err := Open()
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	return s, filepath.Join(home, constants.ConfigDirName, constants.HistoryFileName)
+}
+
+func reopen(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open()
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	return s
+}
+
+func run(id string, end time.Time, score float64) Run {
+	return Run{ID: id, Scenario: "s", Start: end.Add(-time.Minute), End: end, Score: score, Stats: map[string]any{"Score": score}}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	s, _ := openTemp(t)
+	t0 := time.Date(2025, 10, 1, 18, 0, 0, 0, time.UTC)
+	// Added out of order; reads are oldest first.
+	s.Add(run("b", t0.Add(time.Hour), 2))
+	s.Add(run("a", t0, 1))
+	if err := s.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	s.Add(run("c", t0.Add(2*time.Hour), 3))
+	if err := s.Flush(); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	got := reopen(t).All()
+	if len(got) != 3 || got[0].ID != "a" || got[1].ID != "b" || got[2].ID != "c" {
+		t.Fatalf("reloaded %+v", got)
+	}
+	if !got[0].End.Equal(t0) || got[2].Score != 3 || got[2].Stats["Score"] != 3.0 {
+		t.Errorf("run fields not preserved: %+v", got[2])
+	}
+}
+
+func TestStoreTornLine(t *testing.T) {
+	s, path := openTemp(t)
+	t0 := time.Date(2025, 10, 1, 18, 0, 0, 0, time.UTC)
+	s.Add(run("a", t0, 1))
+	if err := s.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	// A crash mid-append leaves a partial line without a newline.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"id":"b","scenario":"s","sco`)
+	f.Close()
+
+	s = reopen(t)
+	if s.Len() != 1 {
+		t.Fatalf("torn line should be skipped, got %d runs", s.Len())
+	}
+	s.Add(run("c", t0.Add(time.Hour), 3))
+	if err := s.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := os.ReadFile(path)
+	if strings.Contains(string(b), `"sco{`) || strings.Contains(string(b), `"id":"b"`) {
+		t.Errorf("torn line not repaired:\n%s", b)
+	}
+	if got := reopen(t).All(); len(got) != 2 || got[1].ID != "c" {
+		t.Errorf("after repair got %+v", got)
+	}
+}
+
+func TestStoreSchemaRewrite(t *testing.T) {
+	_, path := openTemp(t)
+	old := `{"version":1}` + "\n" + `{"id":"a","scenario":"s","end":"2025-10-01T18:00:00Z","score":1}` + "\n"
+	if err := os.WriteFile(path, []byte(old), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s := reopen(t)
+	if s.Len() != 0 {
+		t.Fatalf("stale cache should be discarded, got %d runs", s.Len())
+	}
+	s.Add(run("b", time.Date(2025, 10, 2, 18, 0, 0, 0, time.UTC), 2))
+	if err := s.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 2 || lines[0] != fmt.Sprintf(`{"version":%d}`, schemaVersion) || !strings.Contains(lines[1], `"id":"b"`) {
+		t.Errorf("cache not rewritten at the current schema:\n%s", b)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+}
+
+func TestStoreDuplicateAdd(t *testing.T) {
+	s, _ := openTemp(t)
+	t0 := time.Date(2025, 10, 1, 18, 0, 0, 0, time.UTC)
+	s.Add(run("a", t0, 1))
+	s.Add(run("a", t0, 5))
+	if s.Len() != 1 {
+		t.Fatalf("duplicate ID should replace, got %d runs", s.Len())
+	}
+	if err := s.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	got := reopen(t).All()
+	if len(got) != 1 || got[0].Score != 5 {
+		t.Errorf("reloaded %+v, want the latest run a", got)
+	}
+}
@@ -0,0 +1,120 @@
+package killstats
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Derived stats keys, stored alongside the stats file's own key-value block.
+const (
+	KeyIntervalVariance = "Kill Interval Variance"    // seconds²
+	KeyIntervalCV       = "Kill Interval CV"          // standard deviation / mean
+	KeySlowestInterval  = "Slowest 10% Kill Interval" // mean of the slowest tenth, seconds
+	KeyFirstHalf        = "First Half Avg Interval"   // seconds
+	KeySecondHalf       = "Second Half Avg Interval"  // seconds
+	KeyPaceChange       = "Pace Change"               // second half vs first half (0.1 = 10% slower)
+	KeyFastStreak       = "Longest Fast Kill Streak"  // consecutive kills faster than the median
+	KeyOvershotKills    = "Overshot Kills"
+	KeyCheatedKills     = "Cheated Kills"
+)
+
+// Kill event columns: Kill #, Timestamp, Bot, Weapon, TTK, Shots, Hits, Accuracy,
+// Damage Done, Damage Possible, Efficiency, Cheated, OverShots.
+const (
+	colCheated   = 11
+	colOverShots = 12
+)
+
+// minIntervals is the fewest kill intervals needed for the timing metrics.
+const minIntervals = 4
+
+// Derive computes within-run consistency metrics from the kill events of one run.
+// times holds the parsed timestamp of each kill in order; timing metrics are omitted
+// when there are too few kills, while the overshot and cheated counts are always set.
+func Derive(events [][]string, times []time.Time) map[string]any {
+	out := map[string]any{
+		KeyOvershotKills: countNonZero(events, colOverShots),
+		KeyCheatedKills:  countNonZero(events, colCheated),
+	}
+	var iv []float64
+	for i := 1; i < len(times); i++ {
+		// Kovaak's timestamps carry no date, so a run across midnight goes backwards; skip it.
+		if dt := times[i].Sub(times[i-1]).Seconds(); dt > 0 {
+			iv = append(iv, dt)
+		}
+	}
+	if len(iv) < minIntervals {
+		return out
+	}
+
+	mean, variance := meanVar(iv)
+	out[KeyIntervalVariance] = variance
+	if mean > 0 {
+		out[KeyIntervalCV] = math.Sqrt(variance) / mean
+	}
+
+	sorted := append([]float64(nil), iv...)
+	sort.Float64s(sorted)
+	n := max(1, int(math.Ceil(float64(len(sorted))*0.1)))
+	slow, _ := meanVar(sorted[len(sorted)-n:])
+	out[KeySlowestInterval] = slow
+
+	half := len(iv) / 2
+	first, _ := meanVar(iv[:half])
+	second, _ := meanVar(iv[len(iv)-half:])
+	out[KeyFirstHalf] = first
+	out[KeySecondHalf] = second
+	if first > 0 {
+		out[KeyPaceChange] = second/first - 1
+	}
+
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + median) / 2
+	}
+	streak, longest := 0, 0
+	for _, v := range iv {
+		if v < median {
+			streak++
+			longest = max(longest, streak)
+		} else {
+			streak = 0
+		}
+	}
+	out[KeyFastStreak] = longest
+	return out
+}
+
+// countNonZero counts events whose numeric column col is non-zero.
+func countNonZero(events [][]string, col int) int {
+	n := 0
+	for _, row := range events {
+		if len(row) <= col {
+			continue
+		}
+		if v, err := strconv.ParseFloat(strings.TrimSpace(row[col]), 64); err == nil && v != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// meanVar returns the mean and population variance of vals.
+func meanVar(vals []float64) (float64, float64) {
+	if len(vals) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range vals {
+		sum += v
+	}
+	m := sum / float64(len(vals))
+	var ss float64
+	for _, v := range vals {
+		ss += (v - m) * (v - m)
+	}
+	return m, ss / float64(len(vals))
+}
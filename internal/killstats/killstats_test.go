@@ -0,0 +1,72 @@
+package killstats
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"refleks/internal/parser"
+)
+
+func TestDeriveSynthetic(t *testing.T) {
+	start := time.Date(2025, 10, 2, 18, 0, 0, 0, time.UTC)
+	// Four quick kills, then a slowing second half.
+	gaps := []float64{0.5, 0.5, 0.5, 0.5, 1, 1, 1, 2}
+	times := []time.Time{start}
+	events := [][]string{{"1", "", "", "", "", "", "", "", "", "", "", "0", "0"}}
+	at := start
+	for i, g := range gaps {
+		at = at.Add(time.Duration(g * float64(time.Second)))
+		times = append(times, at)
+		over := "0"
+		if i%3 == 0 {
+			over = "2"
+		}
+		events = append(events, []string{"", "", "", "", "", "", "", "", "", "", "", "0", over})
+	}
+	got := Derive(events, times)
+	want := map[string]any{
+		KeyFirstHalf:       0.5,
+		KeySecondHalf:      1.25,
+		KeyPaceChange:      1.5,
+		KeySlowestInterval: 2.0,
+		KeyFastStreak:      4,
+		KeyOvershotKills:   3,
+		KeyCheatedKills:    0,
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %v, want %v", k, got[k], v)
+		}
+	}
+	if cv, _ := got[KeyIntervalCV].(float64); cv <= 0.4 || cv >= 0.7 {
+		t.Errorf("%s = %v, want a moderate spread", KeyIntervalCV, got[KeyIntervalCV])
+	}
+
+	if few := Derive(events[:3], times[:3]); len(few) != 2 {
+		t.Errorf("too few kills should only yield counts, got %v", few)
+	}
+}
+
+func TestDeriveStatsFile(t *testing.T) {
+	path := filepath.Join("../../testdata/stats", "VT 1w3ts Intermediate S5 - Challenge - 2025.10.02-18.36.37 Stats.csv")
+	events, _, err := parser.ParseStatsFile(path)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	var times []time.Time
+	for _, row := range events {
+		if tod, err := time.Parse("15:04:05.000", row[1]); err == nil {
+			times = append(times, tod)
+		}
+	}
+	got := Derive(events, times)
+	for _, k := range []string{KeyIntervalVariance, KeyIntervalCV, KeySlowestInterval, KeyFirstHalf, KeySecondHalf, KeyPaceChange} {
+		if v, ok := got[k].(float64); !ok || v != v {
+			t.Errorf("%s missing or NaN: %v", k, got[k])
+		}
+	}
+	if got[KeySlowestInterval].(float64) < got[KeyFirstHalf].(float64) {
+		t.Errorf("slowest kills should be slower than the average: %v", got)
+	}
+}
@@ -0,0 +1,230 @@
+package kinematics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"refleks/internal/models"
+	"refleks/internal/sens"
+)
+
+// Distances and speeds below are in counts and converted with the trace's scale.
+const (
+	// flickBin is the speed resolution within a flick.
+	flickBin = 10 * time.Millisecond
+	// minFlickCounts is the shortest flick that is analysed.
+	minFlickCounts = 20.0
+	// onsetCounts is how far the mouse must move for a flick to have started.
+	onsetCounts = 5.0
+	// A submovement is a stretch of bins faster than submoveRatio of the flick's
+	// peak speed, and never slower than minSubmoveSpeed counts per second.
+	submoveRatio    = 0.1
+	minSubmoveSpeed = 200.0
+	// The mouse has settled once it stays within settleRatio of the distance (at
+	// least minSettleCounts) of the target.
+	settleRatio     = 0.05
+	minSettleCounts = 10.0
+)
+
+// Direction words, in the order groups are reported.
+var directions = []string{"left", "right", "up", "down"}
+
+// Flicks splits a trace into one flick per kill, each running from the previous kill
+// (or start, for the first) to the kill. Distances and speeds are in sc's unit.
+func Flicks(raw []models.MousePoint, sc sens.Scale, kills []time.Time, start time.Time) []models.Flick {
+	out := make([]models.Flick, 0, len(kills))
+	if len(raw) == 0 {
+		return out
+	}
+	trace := sens.Convert(raw, sc)
+	from := start
+	for i, k := range kills {
+		if !k.After(from) {
+			continue
+		}
+		f := flick(trace, sc.Mean(), from, k)
+		f.Kill = i + 1
+		f.Start = from.Sub(start).Seconds()
+		f.End = k.Sub(start).Seconds()
+		out = append(out, f)
+		from = k
+	}
+	return out
+}
+
+// GroupFlicks averages measured flicks by direction and by distance band, split at
+// the median distance.
+func GroupFlicks(flicks []models.Flick) []models.FlickGroup {
+	var dists []float64
+	for _, f := range flicks {
+		if f.Measured {
+			dists = append(dists, f.Distance)
+		}
+	}
+	out := []models.FlickGroup{}
+	if len(dists) == 0 {
+		return out
+	}
+	sort.Float64s(dists)
+	median := dists[len(dists)/2]
+	type key struct{ dir, band string }
+	groups := make(map[key]*models.FlickGroup)
+	for _, f := range flicks {
+		if !f.Measured {
+			continue
+		}
+		k := key{f.Direction, "short"}
+		if f.Distance > median {
+			k.band = "long"
+		}
+		g, ok := groups[k]
+		if !ok {
+			g = &models.FlickGroup{Direction: k.dir, Band: k.band}
+			groups[k] = g
+		}
+		g.Flicks++
+		g.MeanDistance += f.Distance
+		g.MeanReactionSec += f.ReactionSec
+		g.MeanPeakSpeed += f.PeakSpeed
+		g.MeanOvershoot += f.Overshoot
+		g.MeanUndershoot += f.Undershoot
+		g.MeanCorrections += float64(f.Corrections)
+		g.MeanSettleSec += f.SettleSec
+	}
+	for _, dir := range directions {
+		for _, band := range []string{"short", "long"} {
+			g, ok := groups[key{dir, band}]
+			if !ok {
+				continue
+			}
+			n := float64(g.Flicks)
+			g.MeanDistance /= n
+			g.MeanReactionSec /= n
+			g.MeanPeakSpeed /= n
+			g.MeanOvershoot /= n
+			g.MeanUndershoot /= n
+			g.MeanCorrections /= n
+			g.MeanSettleSec /= n
+			out = append(out, *g)
+		}
+	}
+	return out
+}
+
+// flick measures the movement from one kill to the next; unit is the trace's scale
+// of one count.
+func flick(trace []models.TracePoint, unit float64, from, to time.Time) models.Flick {
+	var f models.Flick
+	p0, p1 := positionAt(trace, from), positionAt(trace, to)
+	dx, dy := p1.x-p0.x, p1.y-p0.y
+	f.Distance = math.Hypot(dx, dy)
+	// Mouse counts grow downwards, so up is negative y.
+	f.AngleDeg = math.Atan2(-dy, dx) * 180 / math.Pi
+	f.Direction = direction(dx, dy)
+	if f.Distance < minFlickCounts*unit {
+		return f
+	}
+	f.Measured = true
+	ux, uy := dx/f.Distance, dy/f.Distance
+
+	// Samples inside (from, to].
+	lo := sort.Search(len(trace), func(i int) bool { return trace[i].TS.After(from) })
+	hi := sort.Search(len(trace), func(i int) bool { return trace[i].TS.After(to) })
+	seg := trace[lo:hi]
+
+	onset := to
+	for _, s := range seg {
+		if math.Hypot(s.X-p0.x, s.Y-p0.y) >= onsetCounts*unit {
+			onset = s.TS
+			break
+		}
+	}
+	f.ReactionSec = onset.Sub(from).Seconds()
+
+	// Speed and progress along the flick per bin.
+	n := max(1, int((to.Sub(from)+flickBin-1)/flickBin))
+	speed := make([]float64, n)
+	progress := make([]float64, n)
+	prev := p0
+	b := 0
+	maxProgress := 0.0
+	for _, s := range seg {
+		p := point{s.X, s.Y}
+		nb := min(n-1, max(0, int((s.TS.Sub(from)-1)/flickBin)))
+		for ; b < nb; b++ {
+			progress[b+1] = progress[b]
+		}
+		speed[nb] += math.Hypot(p.x-prev.x, p.y-prev.y) / flickBin.Seconds()
+		progress[nb] = (p.x-p0.x)*ux + (p.y-p0.y)*uy
+		maxProgress = math.Max(maxProgress, progress[nb])
+		prev = p
+	}
+	for ; b < n-1; b++ {
+		progress[b+1] = progress[b]
+	}
+
+	peakBin := 0
+	for i, v := range speed {
+		if v > speed[peakBin] {
+			peakBin = i
+		}
+	}
+	f.PeakSpeed = speed[peakBin]
+	thr := math.Max(submoveRatio*f.PeakSpeed, minSubmoveSpeed*unit)
+	primaryEnd := n - 1
+	for i := peakBin + 1; i < n; i++ {
+		if speed[i] < thr {
+			primaryEnd = i
+			break
+		}
+	}
+	if maxProgress > f.Distance {
+		f.Overshoot = (maxProgress - f.Distance) / f.Distance
+	} else {
+		f.Undershoot = math.Max(0, (f.Distance-progress[primaryEnd])/f.Distance)
+	}
+	for i := primaryEnd + 1; i < n; i++ {
+		if speed[i] >= thr && speed[i-1] < thr {
+			f.Corrections++
+		}
+	}
+
+	radius := math.Max(settleRatio*f.Distance, minSettleCounts*unit)
+	settled := onset
+	for i, s := range seg {
+		if math.Hypot(s.X-p1.x, s.Y-p1.y) > radius {
+			settled = to
+			if i+1 < len(seg) {
+				settled = seg[i+1].TS
+			}
+		}
+	}
+	if settled.After(onset) {
+		f.SettleSec = settled.Sub(onset).Seconds()
+	}
+	return f
+}
+
+// positionAt returns the position of the last sample at or before t, or of the
+// first sample when t precedes the trace.
+func positionAt(trace []models.TracePoint, t time.Time) point {
+	i := sort.Search(len(trace), func(i int) bool { return trace[i].TS.After(t) })
+	if i > 0 {
+		i--
+	}
+	return point{trace[i].X, trace[i].Y}
+}
+
+func direction(dx, dy float64) string {
+	if math.Abs(dx) >= math.Abs(dy) {
+		if dx < 0 {
+			return "left"
+		}
+		return "right"
+	}
+	if dy < 0 {
+		return "up"
+	}
+	return "down"
+}
@@ -0,0 +1,144 @@
+package kinematics
+
+import (
+	"math"
+	"time"
+
+	"refleks/internal/models"
+	"refleks/internal/sens"
+)
+
+// Version identifies the analysis below; bump it when results change so cached
+// kinematics are recomputed.
+const Version = 3
+
+const (
+	// binWidth is the width of a profile bin. Raw input only reports motion, so
+	// speeds are taken per bin rather than between samples.
+	binWidth = 50 * time.Millisecond
+	// stationarySpeed is the bin speed, in counts per second, below which the mouse
+	// counts as stationary. Like every distance threshold here, it is converted with
+	// the trace's scale.
+	stationarySpeed = 40.0
+)
+
+type point struct{ x, y float64 }
+
+// Summarize computes path, speed and motion metrics for a mouse trace in sc's unit.
+func Summarize(raw []models.MousePoint, sc sens.Scale) models.TraceMetrics {
+	return Analyze(raw, sc).Metrics
+}
+
+// Analyze computes the velocity, acceleration and jerk profile of a mouse trace in
+// binWidth bins, with summary metrics and the velocity spectrum over the whole trace,
+// in sc's unit.
+func Analyze(raw []models.MousePoint, sc sens.Scale) models.TraceKinematics {
+	k := models.TraceKinematics{Version: Version, Unit: sc.Unit, BinSec: binWidth.Seconds(), Profile: []models.KinematicSample{}}
+	m := &k.Metrics
+	m.Points = len(raw)
+	trace := sens.Convert(raw, sc)
+	k.Spectrum = spectrum(trace, sc.Mean())
+	if len(raw) < 2 {
+		return k
+	}
+	t0 := trace[0].TS
+	span := trace[len(trace)-1].TS.Sub(t0)
+	m.DurationSec = span.Seconds()
+	n := max(1, int((span+binWidth-1)/binWidth))
+	dist := make([]float64, n)
+	// pos is the position at the end of each bin, seen[b] whether a sample fell in it.
+	pos := make([]point, n)
+	seen := make([]bool, n)
+	lastDir := 0
+	for i := 1; i < len(trace); i++ {
+		dx := trace[i].X - trace[i-1].X
+		dy := trace[i].Y - trace[i-1].Y
+		d := math.Hypot(dx, dy)
+		m.PathLength += d
+		if dir := sign(dx); dir != 0 {
+			if lastDir != 0 && dir != lastDir {
+				m.DirectionChanges++
+			}
+			lastDir = dir
+		}
+		// A sample reports motion up to its timestamp, so bins are closed on the right.
+		b := min(n-1, max(0, int((trace[i].TS.Sub(t0)-1)/binWidth)))
+		dist[b] += d
+		pos[b], seen[b] = point{trace[i].X, trace[i].Y}, true
+	}
+	start := point{trace[0].X, trace[0].Y}
+	for b := range pos {
+		if !seen[b] {
+			pos[b] = start
+			if b > 0 {
+				pos[b] = pos[b-1]
+			}
+		}
+	}
+	if m.DurationSec > 0 {
+		m.MeanSpeed = m.PathLength / m.DurationSec
+	}
+
+	binSec := binWidth.Seconds()
+	var prevSpeed, prevAccel, sumAccel, sumJerk float64
+	var moved float64
+	from := start
+	moving := false
+	for b := 0; b < n; b++ {
+		s := models.KinematicSample{T: (float64(b) + 0.5) * binSec, Speed: dist[b] / binSec}
+		if b > 0 {
+			s.Accel = (s.Speed - prevSpeed) / binSec
+			sumAccel += math.Abs(s.Accel)
+			m.PeakAccel = math.Max(m.PeakAccel, math.Abs(s.Accel))
+		}
+		if b > 1 {
+			s.Jerk = (s.Accel - prevAccel) / binSec
+			sumJerk += math.Abs(s.Jerk)
+			m.PeakJerk = math.Max(m.PeakJerk, math.Abs(s.Jerk))
+		}
+		m.PeakSpeed = math.Max(m.PeakSpeed, s.Speed)
+		prevSpeed, prevAccel = s.Speed, s.Accel
+		k.Profile = append(k.Profile, s)
+
+		// Movements run between stationary bins; each contributes its net displacement.
+		if s.Speed >= stationarySpeed*sc.Mean() {
+			if !moving {
+				moving = true
+				m.Movements++
+				if b > 0 {
+					from = pos[b-1]
+				}
+			}
+			m.MovingSec += binSec
+			moved += dist[b]
+		} else if moving {
+			moving = false
+			m.Displacement += math.Hypot(pos[b-1].x-from.x, pos[b-1].y-from.y)
+		}
+	}
+	if moving {
+		m.Displacement += math.Hypot(pos[n-1].x-from.x, pos[n-1].y-from.y)
+	}
+	if moved > 0 {
+		m.PathEfficiency = m.Displacement / moved
+	}
+	m.MovingSec = math.Min(m.MovingSec, m.DurationSec)
+	m.StationarySec = m.DurationSec - m.MovingSec
+	if n > 1 {
+		m.MeanAccel = sumAccel / float64(n-1)
+	}
+	if n > 2 {
+		m.MeanJerk = sumJerk / float64(n-2)
+	}
+	return k
+}
+
+func sign(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,147 @@
+package kinematics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"refleks/internal/models"
+	"refleks/internal/sens"
+)
+
+func TestAnalyze(t *testing.T) {
+	t0 := time.Date(2025, 10, 1, 18, 0, 0, 0, time.UTC)
+	trace := []models.MousePoint{{TS: t0}}
+	x := int32(0)
+	at := 0 * time.Millisecond
+	move := func(dx int32, steps int) {
+		for i := 0; i < steps; i++ {
+			at += 10 * time.Millisecond
+			x += dx
+			trace = append(trace, models.MousePoint{TS: t0.Add(at), X: x})
+		}
+	}
+	// Half a second right at 10000 counts/s, half a second still, then back left.
+	move(100, 50)
+	at += 500 * time.Millisecond
+	move(-100, 50)
+
+	k := Analyze(trace, sens.Counts)
+	m := k.Metrics
+	if m.PathLength != 10000 || m.DirectionChanges != 1 || m.Movements != 2 {
+		t.Fatalf("unexpected path metrics %+v", m)
+	}
+	if math.Abs(m.PathEfficiency-1) > 1e-9 || m.Displacement != 10000 {
+		t.Errorf("straight movements should be fully efficient, got %+v", m)
+	}
+	if math.Abs(m.PeakSpeed-10000) > 1e-6 || m.PeakAccel == 0 || m.PeakJerk == 0 {
+		t.Errorf("unexpected speed profile %+v", m)
+	}
+	if math.Abs(m.StationarySec-0.5) > k.BinSec+1e-9 || math.Abs(m.MovingSec+m.StationarySec-m.DurationSec) > 1e-9 {
+		t.Errorf("moving %.2fs stationary %.2fs of %.2fs", m.MovingSec, m.StationarySec, m.DurationSec)
+	}
+	if len(k.Profile) != int(math.Ceil(m.DurationSec/k.BinSec)) || k.Version != Version {
+		t.Errorf("unexpected profile of %d bins", len(k.Profile))
+	}
+
+	// A wobbling path is less efficient than a straight one.
+	trace = trace[:1]
+	x, at = 0, 0
+	move(100, 10)
+	move(-50, 10)
+	if e := Summarize(trace, sens.Counts).PathEfficiency; e > 0.5 {
+		t.Errorf("wobbling efficiency = %v, want <= 0.5", e)
+	}
+}
+
+func TestFlicks(t *testing.T) {
+	t0 := time.Date(2025, 10, 1, 18, 0, 0, 0, time.UTC)
+	trace := []models.MousePoint{{TS: t0}}
+	x := int32(0)
+	at := time.Duration(0)
+	move := func(from time.Duration, dx int32, steps int) {
+		at = from
+		for i := 0; i < steps; i++ {
+			at += 5 * time.Millisecond
+			x += dx
+			trace = append(trace, models.MousePoint{TS: t0.Add(at), X: x})
+		}
+	}
+	// Right by 1000: 1100 at 10000 counts/s, then a correction back.
+	move(100*time.Millisecond, 50, 22)
+	move(at+50*time.Millisecond, -20, 5)
+	// Left by 500: stops 100 short, then corrects.
+	move(600*time.Millisecond, -50, 8)
+	move(at+100*time.Millisecond, -25, 4)
+	kills := []time.Time{t0.Add(500 * time.Millisecond), t0.Add(time.Second)}
+
+	fl := Flicks(trace, sens.Counts, kills, t0)
+	if len(fl) != 2 {
+		t.Fatalf("got %d flicks, want 2", len(fl))
+	}
+	r, l := fl[0], fl[1]
+	if !r.Measured || r.Direction != "right" || r.Distance != 1000 || r.AngleDeg != 0 {
+		t.Fatalf("unexpected first flick %+v", r)
+	}
+	if math.Abs(r.ReactionSec-0.105) > 1e-9 || math.Abs(r.PeakSpeed-10000) > 1e-6 {
+		t.Errorf("reaction %.3fs peak %.0f", r.ReactionSec, r.PeakSpeed)
+	}
+	if math.Abs(r.Overshoot-0.1) > 1e-9 || r.Undershoot != 0 || r.Corrections != 1 {
+		t.Errorf("first flick overshoot %.2f undershoot %.2f corrections %d", r.Overshoot, r.Undershoot, r.Corrections)
+	}
+	// Settled when the correction brings it within 50 counts, at 1040.
+	if math.Abs(r.SettleSec-(0.275-0.105)) > 1e-9 {
+		t.Errorf("settle = %.3fs", r.SettleSec)
+	}
+	if l.Direction != "left" || l.Distance != 500 || math.Abs(l.Undershoot-0.2) > 1e-9 || l.Overshoot != 0 || l.Corrections != 1 {
+		t.Errorf("unexpected second flick %+v", l)
+	}
+
+	// In degrees at CS:GO sensitivity 2 (0.044°/count) the flicks measure the same.
+	deg, ok := sens.ScaleFromStats(map[string]any{"Sens Scale": "CSGO", "Horiz Sens": 2.0, "DPI": 800.0}, sens.UnitDegrees)
+	if !ok {
+		t.Fatal("expected a degree conversion for CSGO sensitivity")
+	}
+	if d := Flicks(trace, deg, kills, t0); math.Abs(d[0].Distance-44) > 1e-9 || d[0].Corrections != 1 || d[1].Overshoot != 0 || math.Abs(d[0].Overshoot-r.Overshoot) > 1e-9 {
+		t.Errorf("unexpected flicks in degrees %+v", d)
+	}
+
+	g := GroupFlicks(fl)
+	if len(g) != 2 || g[0].Direction != "left" || g[1].Direction != "right" || g[1].MeanOvershoot != r.Overshoot {
+		t.Errorf("unexpected groups %+v", g)
+	}
+}
+
+func TestSpectrum(t *testing.T) {
+	t0 := time.Date(2025, 10, 1, 18, 0, 0, 0, time.UTC)
+	// Ten seconds of slow side-to-side tracking sampled at 1 kHz, optionally with an
+	// 8 Hz tremor on top.
+	trace := func(tremor float64) []models.MousePoint {
+		var out []models.MousePoint
+		for ms := 0; ms <= 10000; ms++ {
+			s := float64(ms) / 1000
+			x := 3000*math.Sin(2*math.Pi*0.5*s) + tremor*math.Sin(2*math.Pi*8*s)
+			out = append(out, models.MousePoint{TS: t0.Add(time.Duration(ms) * time.Millisecond), X: int32(math.Round(x))})
+		}
+		return out
+	}
+	smooth := Analyze(trace(0), sens.Counts).Spectrum
+	shaky := Analyze(trace(30), sens.Counts).Spectrum
+	if smooth.Windows == 0 || shaky.Windows != smooth.Windows {
+		t.Fatalf("analysed %d and %d windows", smooth.Windows, shaky.Windows)
+	}
+	if smooth.TremorRatio > 0.01 || shaky.TremorRatio < 0.05 {
+		t.Errorf("tremor ratio smooth %.4f shaky %.4f", smooth.TremorRatio, shaky.TremorRatio)
+	}
+	if math.Abs(shaky.PeakTremorHz-8) > 0.5 || shaky.TremorRMS <= smooth.TremorRMS {
+		t.Errorf("unexpected tremor peak %.2f Hz, rms %.1f", shaky.PeakTremorHz, shaky.TremorRMS)
+	}
+	if smooth.Smoothness >= 0 || shaky.Smoothness >= smooth.Smoothness {
+		t.Errorf("smoothness smooth %.3f shaky %.3f", smooth.Smoothness, shaky.Smoothness)
+	}
+
+	tr := SmoothnessTrend("s", []models.SmoothnessPoint{{TremorRatio: 0.3, Smoothness: -3}, {TremorRatio: 0.2, Smoothness: -2}, {TremorRatio: 0.1, Smoothness: -1}})
+	if math.Abs(tr.TremorSlope+0.1) > 1e-9 || math.Abs(tr.SmoothnessSlope-1) > 1e-9 {
+		t.Errorf("unexpected slopes %+v", tr)
+	}
+}
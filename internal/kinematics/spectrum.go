@@ -0,0 +1,238 @@
+package kinematics
+
+import (
+	"math"
+	"math/cmplx"
+	"time"
+
+	"refleks/internal/models"
+)
+
+const (
+	// spectrumBin is the resampling period of the velocity signal (100 Hz). Like the
+	// profile, velocity is the motion reported within each bin.
+	spectrumBin = 10 * time.Millisecond
+	// spectrumWindow is the number of bins per analysis window (2.56 s). Windows where
+	// the mouse is mostly still are skipped.
+	spectrumWindow = 256
+	// The tremor band covers physiological tremor and fine jitter.
+	tremorLowHz  = 6.0
+	tremorHighHz = 12.0
+	// Power below minSpectrumHz is drift rather than motion and left out of ratios.
+	minSpectrumHz = 0.5
+	// psdMaxHz limits the reported spectrum to the range worth plotting.
+	psdMaxHz = 25.0
+	// Spectral arc length (SPARC) follows Balasubramanian et al. (2015): the speed
+	// spectrum is zero-padded sparcPad times over, normalised, and measured up to
+	// the last frequency below sparcCutoffHz whose magnitude reaches sparcThreshold.
+	sparcPad       = 16
+	sparcCutoffHz  = 10.0
+	sparcThreshold = 0.05
+)
+
+// spectrum measures tremor and smoothness from the velocity of a converted trace;
+// unit is the trace's scale of one count.
+func spectrum(trace []models.TracePoint, unit float64) models.TraceSpectrum {
+	rate := 1 / spectrumBin.Seconds()
+	s := models.TraceSpectrum{SampleHz: rate, BandLowHz: tremorLowHz, BandHighHz: tremorHighHz, PSD: []models.SpectrumBin{}}
+	if len(trace) < 2 {
+		return s
+	}
+	vx, vy := velocity(trace)
+	df := rate / spectrumWindow
+	psd := make([]float64, spectrumWindow/2+1)
+	hann := make([]float64, spectrumWindow)
+	var norm float64
+	for i := range hann {
+		hann[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(spectrumWindow-1))
+		norm += hann[i] * hann[i]
+	}
+	var sparc float64
+	for at := 0; at+spectrumWindow <= len(vx); at += spectrumWindow {
+		wx, wy := vx[at:at+spectrumWindow], vy[at:at+spectrumWindow]
+		speed := make([]float64, spectrumWindow)
+		var mean float64
+		for i := range speed {
+			speed[i] = math.Hypot(wx[i], wy[i])
+			mean += speed[i]
+		}
+		if mean/spectrumWindow < stationarySpeed*unit {
+			continue
+		}
+		s.Windows++
+		// Welch estimate: Hann-windowed, mean-removed velocity on both axes.
+		fx, fy := fft(tapered(wx, hann)), fft(tapered(wy, hann))
+		for k := range psd {
+			p := (sq(cmplx.Abs(fx[k])) + sq(cmplx.Abs(fy[k]))) / (rate * norm)
+			if k > 0 && k < spectrumWindow/2 {
+				p *= 2
+			}
+			psd[k] += p
+		}
+		sparc += arcLength(speed, rate)
+	}
+	if s.Windows == 0 {
+		return s
+	}
+	var band, total, peak float64
+	for k := range psd {
+		psd[k] /= float64(s.Windows)
+		f := float64(k) * df
+		if f >= minSpectrumHz {
+			total += psd[k] * df
+		}
+		if f >= tremorLowHz && f <= tremorHighHz {
+			band += psd[k] * df
+			if psd[k] > peak {
+				peak, s.PeakTremorHz = psd[k], f
+			}
+		}
+		if f <= psdMaxHz {
+			s.PSD = append(s.PSD, models.SpectrumBin{Hz: f, Power: psd[k]})
+		}
+	}
+	s.TremorRMS = math.Sqrt(band)
+	if total > 0 {
+		s.TremorRatio = band / total
+	}
+	s.Smoothness = sparc / float64(s.Windows)
+	return s
+}
+
+// velocity resamples a trace into spectrumBin bins of x and y velocity. Samples
+// report motion up to their timestamp, so bins are closed on the right.
+func velocity(trace []models.TracePoint) ([]float64, []float64) {
+	t0 := trace[0].TS
+	n := max(1, int((trace[len(trace)-1].TS.Sub(t0)+spectrumBin-1)/spectrumBin))
+	vx, vy := make([]float64, n), make([]float64, n)
+	rate := 1 / spectrumBin.Seconds()
+	for i := 1; i < len(trace); i++ {
+		b := min(n-1, max(0, int((trace[i].TS.Sub(t0)-1)/spectrumBin)))
+		vx[b] += (trace[i].X - trace[i-1].X) * rate
+		vy[b] += (trace[i].Y - trace[i-1].Y) * rate
+	}
+	return vx, vy
+}
+
+// arcLength returns the spectral arc length of a speed window: 0 for a perfectly
+// smooth movement, more negative as it gets jerkier.
+func arcLength(speed []float64, rate float64) float64 {
+	n := sparcPad
+	for n < sparcPad*len(speed) {
+		n *= 2
+	}
+	padded := make([]float64, n)
+	copy(padded, speed)
+	f := fft(padded)
+	df := rate / float64(n)
+	mag := make([]float64, 0, n/2)
+	var top float64
+	for k := 0; k <= n/2 && float64(k)*df <= sparcCutoffHz; k++ {
+		m := cmplx.Abs(f[k])
+		mag = append(mag, m)
+		top = math.Max(top, m)
+	}
+	if top == 0 || len(mag) < 2 {
+		return 0
+	}
+	last := 0
+	for k, m := range mag {
+		mag[k] = m / top
+		if mag[k] >= sparcThreshold {
+			last = k
+		}
+	}
+	if last == 0 {
+		return 0
+	}
+	step := 1 / float64(last)
+	var length float64
+	for k := 1; k <= last; k++ {
+		length -= math.Hypot(step, mag[k]-mag[k-1])
+	}
+	return length
+}
+
+// tapered returns v with its mean removed and the window applied.
+func tapered(v, window []float64) []float64 {
+	var mean float64
+	for _, x := range v {
+		mean += x
+	}
+	mean /= float64(len(v))
+	out := make([]float64, len(v))
+	for i, x := range v {
+		out[i] = (x - mean) * window[i]
+	}
+	return out
+}
+
+// fft returns the discrete Fourier transform of v, whose length must be a power of two.
+func fft(v []float64) []complex128 {
+	n := len(v)
+	out := make([]complex128, n)
+	for i, x := range v {
+		out[i] = complex(x, 0)
+	}
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			out[i], out[j] = out[j], out[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		w := cmplx.Rect(1, -2*math.Pi/float64(size))
+		for start := 0; start < n; start += size {
+			t := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				a, b := out[start+k], out[start+k+size/2]*t
+				out[start+k], out[start+k+size/2] = a+b, a-b
+				t *= w
+			}
+		}
+	}
+	return out
+}
+
+// SmoothnessTrend fits how tremor and smoothness change over a scenario's runs,
+// oldest first. Slopes are per run.
+func SmoothnessTrend(scenario string, points []models.SmoothnessPoint) models.SmoothnessTrend {
+	out := models.SmoothnessTrend{Scenario: scenario, Points: points}
+	if out.Points == nil {
+		out.Points = []models.SmoothnessPoint{}
+	}
+	tremor := make([]float64, len(points))
+	smooth := make([]float64, len(points))
+	for i, p := range points {
+		tremor[i], smooth[i] = p.TremorRatio, p.Smoothness
+	}
+	out.TremorSlope, out.SmoothnessSlope = slope(tremor), slope(smooth)
+	return out
+}
+
+// slope returns the least-squares slope of ys against their index.
+func slope(ys []float64) float64 {
+	n := float64(len(ys))
+	if n < 2 {
+		return 0
+	}
+	mx := (n - 1) / 2
+	var my float64
+	for _, y := range ys {
+		my += y
+	}
+	my /= n
+	var num, den float64
+	for i, y := range ys {
+		dx := float64(i) - mx
+		num += dx * (y - my)
+		den += dx * dx
+	}
+	return num / den
+}
+
+func sq(v float64) float64 { return v * v }
@@ -8,8 +8,17 @@ type ScenarioRecord struct {
 	FileName string         `json:"fileName"`
 	Stats    map[string]any `json:"stats"`
 	Events   [][]string     `json:"events"`
-	// Optional mouse trace captured locally. Absent when disabled or unavailable.
-	MouseTrace []MousePoint `json:"mouseTrace,omitempty"`
+	// MouseTrace is the locally captured mouse trace, loaded only for analysis. It is
+	// never sent over IPC; the frontend fetches it with GetTrace.
+	MouseTrace []MousePoint `json:"-"`
+	// TracePoints is the number of samples in the run's trace, 0 when none was captured.
+	TracePoints int `json:"tracePoints,omitempty"`
+	// Hidden is set when the record matches the user's scenario filter rules.
+	// Hidden records are still ingested so rule changes apply without a re-parse.
+	Hidden bool `json:"hidden,omitempty"`
+	// Anomalies lists reason codes when the run's technical stats deviate from the
+	// player's norm (see AnomalyReason).
+	Anomalies []string `json:"anomalies,omitempty"`
 }
 
 // WatcherConfig contains runtime configuration for the watcher.
@@ -34,6 +43,33 @@ type Settings struct {
 	MouseTrackingEnabled bool     `json:"mouseTrackingEnabled"`
 	MouseBufferMinutes   int      `json:"mouseBufferMinutes"`
 	MaxExistingOnStart   int      `json:"maxExistingOnStart"`
+	// ScenarioFilters are include/exclude rules applied to every ingested record.
+	ScenarioFilters []ScenarioFilter `json:"scenarioFilters,omitempty"`
+	// HiddenScenarios lists exact scenario names hidden by the user.
+	HiddenScenarios []string `json:"hiddenScenarios,omitempty"`
+	// ExcludeAnomalousRuns hides runs flagged as technical anomalies from PBs,
+	// predictions and aggregates, like filtered runs.
+	ExcludeAnomalousRuns bool `json:"excludeAnomalousRuns,omitempty"`
+	// CurrentVersionOnly hides runs played on an older hash of their scenario from
+	// PBs, predictions and aggregates.
+	CurrentVersionOnly bool `json:"currentVersionOnly,omitempty"`
+}
+
+// ScenarioFilter is a persisted rule selecting runs by scenario name, mode and duration.
+// Exclude rules hide the runs they select. When any include rule exists, runs
+// selected by none of the include rules are hidden.
+type ScenarioFilter struct {
+	// Action is "exclude" (default) or "include".
+	Action string `json:"action"`
+	// Pattern matches the scenario name, case-insensitively. Empty matches every scenario.
+	Pattern string `json:"pattern"`
+	// Regex treats Pattern as a regular expression instead of a glob (* and ?).
+	Regex bool `json:"regex,omitempty"`
+	// Mode restricts the rule to "Challenge" or "Freeplay" runs. Empty matches both.
+	Mode string `json:"mode,omitempty"`
+	// MinDurationSeconds, when set, narrows the rule to runs shorter than this.
+	// For include rules it is a floor: shorter runs are not considered included.
+	MinDurationSeconds float64 `json:"minDurationSeconds,omitempty"`
 }
 
 // Benchmark models exposed to frontend via Wails
@@ -61,6 +97,14 @@ type MousePoint struct {
 	Y  int32     `json:"y"`
 }
 
+// TracePoint is a mouse trace sample converted into degrees or centimetres, relative
+// to the first sample of the trace.
+type TracePoint struct {
+	TS time.Time `json:"ts"`
+	X  float64   `json:"x"`
+	Y  float64   `json:"y"`
+}
+
 // UpdateInfo describes application update availability and metadata exchanged over IPC.
 type UpdateInfo struct {
 	CurrentVersion string `json:"currentVersion"`
@@ -71,3 +115,725 @@ type UpdateInfo struct {
 	// Optional plain-text notes (best-effort, may be empty)
 	ReleaseNotes string `json:"releaseNotes,omitempty"`
 }
+
+// ArchiveEntry describes a single stats file stored in a monthly archive.
+type ArchiveEntry struct {
+	FileName     string `json:"fileName"`
+	ScenarioName string `json:"scenarioName"`
+	DatePlayed   string `json:"datePlayed"`
+	// Month is the archive bucket in YYYY-MM form, derived from DatePlayed.
+	Month string `json:"month"`
+	// Archive is the file name of the compressed archive holding this entry.
+	Archive    string `json:"archive"`
+	Size       int64  `json:"size"`
+	ArchivedAt string `json:"archivedAt"`
+}
+
+// ArchiveResult summarizes an archive or restore operation exchanged over IPC.
+type ArchiveResult struct {
+	DryRun bool     `json:"dryRun"`
+	Files  int      `json:"files"`
+	Bytes  int64    `json:"bytes"`
+	Months []string `json:"months"`
+	// Per-file problems that did not abort the whole operation.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Session groups consecutive runs whose idle gap stays within the configured session gap.
+type Session struct {
+	// ID is derived from the session start and is stable while its first run stays first.
+	ID    string `json:"id"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// Active is true until the gap has elapsed after the last run (SessionEnded not yet emitted).
+	Active bool `json:"active"`
+	Runs   int  `json:"runs"`
+	// RunIDs are the stats file names of the session's runs, oldest first.
+	RunIDs []string `json:"runIds"`
+	// Scenarios holds per-scenario aggregates in first-played order.
+	Scenarios   []SessionScenario `json:"scenarios"`
+	PlaySeconds float64           `json:"playSeconds"`
+	AvgAccuracy float64           `json:"avgAccuracy"`
+}
+
+// SessionScenario aggregates the runs of one scenario within a session.
+type SessionScenario struct {
+	Name        string  `json:"name"`
+	Runs        int     `json:"runs"`
+	BestScore   float64 `json:"bestScore"`
+	AvgScore    float64 `json:"avgScore"`
+	AvgAccuracy float64 `json:"avgAccuracy"`
+}
+
+// PersonalBest is one step in a scenario's highscore progression.
+type PersonalBest struct {
+	Scenario   string  `json:"scenario"`
+	RunID      string  `json:"runId"`
+	DatePlayed string  `json:"datePlayed"`
+	Score      float64 `json:"score"`
+	// PreviousScore is the best before this run; zero when First is set.
+	PreviousScore float64 `json:"previousScore"`
+	Margin        float64 `json:"margin"`
+	// MarginPct is Margin as a percentage of PreviousScore.
+	MarginPct float64 `json:"marginPct"`
+	// First marks a scenario's first recorded run, which is a PB by definition.
+	First bool `json:"first,omitempty"`
+}
+
+// Distribution summarizes a series of values.
+type Distribution struct {
+	Count  int     `json:"count"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	P90    float64 `json:"p90"`
+	StdDev float64 `json:"stdDev"`
+}
+
+// ScenarioSummary aggregates every visible run of a scenario.
+type ScenarioSummary struct {
+	Scenario    string  `json:"scenario"`
+	Count       int     `json:"count"`
+	PlaySeconds float64 `json:"playSeconds"`
+	BestScore   float64 `json:"bestScore"`
+	MeanScore   float64 `json:"meanScore"`
+	MedianScore float64 `json:"medianScore"`
+	P90Score    float64 `json:"p90Score"`
+	// Accuracy is in the 0..1 range, matching the Accuracy stat.
+	Accuracy Distribution `json:"accuracy"`
+	// TTK covers runs with a Real Avg TTK, in seconds.
+	TTK Distribution `json:"ttk"`
+	// TrendSlope is the least-squares score change per run over the last TrendRuns runs.
+	TrendSlope          float64 `json:"trendSlope"`
+	TrendRuns           int     `json:"trendRuns"`
+	FirstPlayed         string  `json:"firstPlayed"`
+	LastPlayed          string  `json:"lastPlayed"`
+	DaysSinceLastPlayed float64 `json:"daysSinceLastPlayed"`
+	// VersionBoundaries marks where the scenario hash or game version changed, oldest first.
+	VersionBoundaries []VersionBoundary `json:"versionBoundaries,omitempty"`
+}
+
+// HighscorePrediction forecasts when a scenario's personal best will next be beaten.
+type HighscorePrediction struct {
+	Scenario string `json:"scenario"`
+	// PredictedScore is the next PB being forecast: the current best plus a minimal margin.
+	PredictedScore float64 `json:"predictedScore"`
+	// RunsExpected is zero when no forecast could be made; Reason then explains why.
+	RunsExpected int `json:"runsExpected"`
+	// RunsLo and RunsHi bound RunsExpected, widening as confidence drops.
+	RunsLo int `json:"runsLo"`
+	RunsHi int `json:"runsHi"`
+	// ETA assumes the recommended pause between runs; RFC3339, empty when unknown.
+	ETA           string  `json:"eta,omitempty"`
+	OptPauseHours float64 `json:"optPauseHours"`
+	// Confidence is "low", "med" or "high", bucketed from ConfidenceScore (0..1).
+	Confidence      string  `json:"confidence"`
+	ConfidenceScore float64 `json:"confidenceScore"`
+	Sample          int     `json:"sample"`
+	Best            float64 `json:"best"`
+	LastScore       float64 `json:"lastScore"`
+	LastPlayedDays  float64 `json:"lastPlayedDays"`
+	SlopePerDay     float64 `json:"slopePerDay"`
+	SlopePerRun     float64 `json:"slopePerRun"`
+	Reason          string  `json:"reason,omitempty"`
+}
+
+// LengthStats describes the per-session prefix average over the first L runs of a scenario.
+type LengthStats struct {
+	Runs int     `json:"runs"` // L
+	Mean float64 `json:"mean"`
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Std  float64 `json:"std"`
+	P10  float64 `json:"p10"`
+	P90  float64 `json:"p90"`
+	// Count is the number of sessions with at least L runs of the scenario.
+	Count int `json:"count"`
+}
+
+// SessionLengthRecommendation suggests how many attempts of a scenario to play per session.
+type SessionLengthRecommendation struct {
+	Scenario string `json:"scenario"`
+	Sessions int    `json:"sessions"`
+	// Sufficient is false when there are too few sessions to recommend anything;
+	// all recommendations are then zero and Reason explains why.
+	Sufficient bool   `json:"sufficient"`
+	Reason     string `json:"reason,omitempty"`
+
+	WarmupRuns            int `json:"warmupRuns"`
+	OptimalAvgRuns        int `json:"optimalAvgRuns"`
+	OptimalConsistentRuns int `json:"optimalConsistentRuns"`
+	OptimalHighscoreRuns  int `json:"optimalHighscoreRuns"`
+	// RecommendedRuns is the highscore-optimal length; RecommendedLo and RecommendedHi
+	// are its 10th and 90th percentiles when sessions are resampled.
+	RecommendedRuns int `json:"recommendedRuns"`
+	RecommendedLo   int `json:"recommendedLo"`
+	RecommendedHi   int `json:"recommendedHi"`
+
+	// ExpectedBest[L-1] is the mean best score over the first L runs of a session.
+	ExpectedBest []float64     `json:"expectedBest"`
+	ExpectedAvg  []LengthStats `json:"expectedAvg"`
+}
+
+// CurvePoint is one bucket of a normalized performance curve.
+type CurvePoint struct {
+	// X is the run position in the session (1-based) or elapsed session minutes.
+	X float64 `json:"x"`
+	// Mean is the mean score relative to the scenario baseline (0 = baseline, 0.05 = 5% above).
+	Mean  float64 `json:"mean"`
+	Count int     `json:"count"`
+}
+
+// FatigueProfile describes how a scenario's score evolves over a session.
+type FatigueProfile struct {
+	Scenario string `json:"scenario"`
+	Sessions int    `json:"sessions"`
+	Runs     int    `json:"runs"`
+	// Baseline is the scenario's median score over its full history.
+	Baseline float64 `json:"baseline"`
+	// Sufficient is false when there is too little data; Reason then explains why.
+	Sufficient bool         `json:"sufficient"`
+	Reason     string       `json:"reason,omitempty"`
+	ByPosition []CurvePoint `json:"byPosition"`
+	ByElapsed  []CurvePoint `json:"byElapsed"`
+	// WarmupRuns and WarmupMinutes mark where performance plateaus at WarmedLevel.
+	WarmupRuns    int     `json:"warmupRuns"`
+	WarmupMinutes float64 `json:"warmupMinutes"`
+	WarmedLevel   float64 `json:"warmedLevel"`
+	// FatigueOnsetRuns and FatigueOnsetMinutes are zero when no sustained decline is found.
+	FatigueOnsetRuns    int     `json:"fatigueOnsetRuns"`
+	FatigueOnsetMinutes float64 `json:"fatigueOnsetMinutes"`
+	// FatigueDecline is how far the curve ends below WarmedLevel after the onset.
+	FatigueDecline float64 `json:"fatigueDecline"`
+}
+
+// FatigueAlert is the payload of the FatigueDetected event.
+type FatigueAlert struct {
+	SessionID      string  `json:"sessionId"`
+	RunID          string  `json:"runId"`
+	Runs           int     `json:"runs"`
+	ElapsedMinutes float64 `json:"elapsedMinutes"`
+	// WarmedLevel and RecentLevel are relative to each scenario's baseline.
+	WarmedLevel float64 `json:"warmedLevel"`
+	RecentLevel float64 `json:"recentLevel"`
+	Drop        float64 `json:"drop"`
+}
+
+// SensBin is one cm/360 bucket of a sensitivity analysis.
+type SensBin struct {
+	Lo   float64 `json:"lo"`
+	Hi   float64 `json:"hi"`
+	Runs int     `json:"runs"`
+	// Mean is the average score residual after removing the time trend, relative to
+	// the scenario's typical score (0.02 = 2% above trend).
+	Mean   float64 `json:"mean"`
+	StdErr float64 `json:"stdErr"`
+}
+
+// SensitivityAnalysis relates cm/360 to detrended performance for a scenario or a
+// scenario type and recommends the range where the player performs best.
+type SensitivityAnalysis struct {
+	// Group is a scenario name or a scenario type, depending on Kind.
+	Group string    `json:"group"`
+	Kind  string    `json:"kind"` // "scenario" or "type"
+	Runs  int       `json:"runs"`
+	Bins  []SensBin `json:"bins"`
+	// Sufficient is false when no recommendation can be made; Reason then explains why.
+	Sufficient bool    `json:"sufficient"`
+	Reason     string  `json:"reason,omitempty"`
+	BestLo     float64 `json:"bestLo"`
+	BestHi     float64 `json:"bestHi"`
+	// Gain is how far runs in the best range sit above the other runs, relative to trend.
+	Gain float64 `json:"gain"`
+	// Confidence is "low", "med" or "high", derived from the T statistic of Gain.
+	Confidence string  `json:"confidence"`
+	TStat      float64 `json:"tStat"`
+}
+
+// TimeBucket aggregates normalized performance for one hour of day or weekday.
+type TimeBucket struct {
+	// Key is the hour (0-23) or the weekday (0 = Sunday).
+	Key  int `json:"key"`
+	Runs int `json:"runs"`
+	// MeanPercentile is the mean percentile (0..1) of runs within their scenario's history.
+	MeanPercentile float64 `json:"meanPercentile"`
+	// ScoreDelta is the mean score relative to each scenario's median, minus the
+	// same mean over all runs (0.06 = 6% better than usual).
+	ScoreDelta float64 `json:"scoreDelta"`
+	StdErr     float64 `json:"stdErr"`
+	// Sufficient is false when the bucket has too few runs to be trusted.
+	Sufficient bool `json:"sufficient"`
+}
+
+// TimeWindow is a contiguous range of hours [StartHour, EndHour), wrapping past midnight.
+type TimeWindow struct {
+	StartHour  int     `json:"startHour"`
+	EndHour    int     `json:"endHour"`
+	Runs       int     `json:"runs"`
+	ScoreDelta float64 `json:"scoreDelta"`
+	TStat      float64 `json:"tStat"`
+}
+
+// TimeOfDayAnalysis relates normalized performance to when runs are played.
+type TimeOfDayAnalysis struct {
+	Runs      int          `json:"runs"`
+	Scenarios int          `json:"scenarios"`
+	ByHour    []TimeBucket `json:"byHour"`
+	ByWeekday []TimeBucket `json:"byWeekday"`
+	// BestWindow and WorstWindow are omitted when no window has enough runs.
+	BestWindow  *TimeWindow `json:"bestWindow,omitempty"`
+	WorstWindow *TimeWindow `json:"worstWindow,omitempty"`
+	Sufficient  bool        `json:"sufficient"`
+	Reason      string      `json:"reason,omitempty"`
+}
+
+// SetupChange is a stats setting that differs before and after a detected change.
+type SetupChange struct {
+	Key    string `json:"key"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// TrendFinding is a detected plateau or regression in a scenario's score history.
+type TrendFinding struct {
+	Scenario string `json:"scenario"`
+	Kind     string `json:"kind"` // "plateau" or "regression"
+	// Since is when the plateau window or the post-regression period starts (RFC3339).
+	Since    string `json:"since"`
+	Sessions int    `json:"sessions"`
+	// Before and After are the best score before and within a plateau window, or the
+	// mean session score before and after a regression.
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+	// Change is After relative to Before (-0.05 = 5% lower).
+	Change float64 `json:"change"`
+	TStat  float64 `json:"tStat"`
+	// SetupChanges lists settings that changed around a regression.
+	SetupChanges []SetupChange `json:"setupChanges,omitempty"`
+}
+
+// RatingPoint is a category's rating at the end of a day with runs in it.
+type RatingPoint struct {
+	Date         string  `json:"date"` // YYYY-MM-DD, local time
+	Rating       float64 `json:"rating"`
+	RankProgress float64 `json:"rankProgress"`
+	Runs         int     `json:"runs"` // runs in the category that day
+}
+
+// CategoryRating is a cross-scenario skill rating for one scenario category.
+type CategoryRating struct {
+	Category string `json:"category"` // Clicking, Tracking, Switching or Other
+	// Rating is a recency-weighted average of run percentiles against each scenario's
+	// recent runs, on a 0..100 scale: 50 is playing at your usual level.
+	Rating float64 `json:"rating"`
+	// RankProgress is a recency-weighted average of benchmark rank progress over runs
+	// of scenarios with known thresholds (3.4 = 40% of the way from rank 3 to 4).
+	RankProgress float64       `json:"rankProgress"`
+	Runs         int           `json:"runs"`
+	RankedRuns   int           `json:"rankedRuns"`
+	Scenarios    int           `json:"scenarios"`
+	History      []RatingPoint `json:"history"`
+}
+
+// Goal is a player-set target for one metric on one scenario.
+type Goal struct {
+	ID       string `json:"id"`
+	Scenario string `json:"scenario"`
+	// Metric is "score", "accuracy" (0..1) or "ttk" (Real Avg TTK, seconds; lower is better).
+	Metric string  `json:"metric"`
+	Target float64 `json:"target"`
+	// Deadline is optional (RFC3339).
+	Deadline  string `json:"deadline,omitempty"`
+	CreatedAt string `json:"createdAt"`
+	// AchievedAt and AchievedRunID are set by the first run after CreatedAt that meets Target.
+	AchievedAt    string `json:"achievedAt,omitempty"`
+	AchievedRunID string `json:"achievedRunId,omitempty"`
+}
+
+// GoalStatus is a goal evaluated against the run history.
+type GoalStatus struct {
+	Goal Goal `json:"goal"`
+	// Best is the best value of the metric over all runs of the scenario.
+	Best float64 `json:"best"`
+	// Progress is Best relative to Target, capped at 1.
+	Progress float64 `json:"progress"`
+	Runs     int     `json:"runs"`
+	Achieved bool    `json:"achieved"`
+	// ETA is when the daily-best trend reaches Target (RFC3339), empty without a usable trend.
+	ETA string `json:"eta,omitempty"`
+	// OnTrack is set when the ETA falls before the deadline, or there is no deadline.
+	OnTrack bool `json:"onTrack"`
+	Overdue bool `json:"overdue"`
+}
+
+// RoutineItem is one scenario of a routine and how many runs it needs per day.
+type RoutineItem struct {
+	Scenario string `json:"scenario"`
+	Runs     int    `json:"runs"`
+}
+
+// Routine is a list of scenarios to play on scheduled weekdays.
+type Routine struct {
+	ID    string        `json:"id"`
+	Name  string        `json:"name"`
+	Items []RoutineItem `json:"items"`
+	// Days are the scheduled weekdays (0 = Sunday). Empty means every day.
+	Days      []int  `json:"days"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// RoutineItemProgress counts today's runs of one routine item.
+type RoutineItemProgress struct {
+	Scenario string `json:"scenario"`
+	Target   int    `json:"target"`
+	Done     int    `json:"done"`
+}
+
+// RoutineStatus is a routine matched against today's runs and its recent history.
+type RoutineStatus struct {
+	Routine        Routine               `json:"routine"`
+	ScheduledToday bool                  `json:"scheduledToday"`
+	Items          []RoutineItemProgress `json:"items"`
+	// Progress is today's completed share of all required runs (0..1).
+	Progress  float64 `json:"progress"`
+	Completed bool    `json:"completed"`
+	// NextScenario is the first item still short of its runs today; empty when done.
+	NextScenario string `json:"nextScenario,omitempty"`
+	// Streak counts consecutive scheduled days completed, up to today.
+	Streak     int `json:"streak"`
+	BestStreak int `json:"bestStreak"`
+	// Adherence is the share of scheduled days completed over the last 28 days.
+	Adherence float64 `json:"adherence"`
+}
+
+// DayActivity is the practice volume of one local calendar day.
+type DayActivity struct {
+	Date      string  `json:"date"` // YYYY-MM-DD
+	Seconds   float64 `json:"seconds"`
+	Runs      int     `json:"runs"`
+	Scenarios int     `json:"scenarios"`
+	// Level is the heatmap intensity: 0 for no practice, 1..4 by quartile of practised days.
+	Level int `json:"level"`
+}
+
+// WeekActivity is the practice volume of one week, starting on Monday.
+type WeekActivity struct {
+	Week      string  `json:"week"` // Monday, YYYY-MM-DD
+	Seconds   float64 `json:"seconds"`
+	Runs      int     `json:"runs"`
+	Scenarios int     `json:"scenarios"`
+	Days      int     `json:"days"` // days practised
+}
+
+// PracticeVolume summarizes how much the player trains.
+type PracticeVolume struct {
+	TotalSeconds  float64 `json:"totalSeconds"`
+	TotalRuns     int     `json:"totalRuns"`
+	Scenarios     int     `json:"scenarios"`
+	DaysPractised int     `json:"daysPractised"`
+	// CurrentStreak counts consecutive practised days ending today, or yesterday when
+	// today has no runs yet.
+	CurrentStreak int            `json:"currentStreak"`
+	LongestStreak int            `json:"longestStreak"`
+	Days          []DayActivity  `json:"days"`  // practised days, oldest first
+	Weeks         []WeekActivity `json:"weeks"` // weeks with practice, oldest first
+}
+
+// TraceMetrics summarizes the motion in a mouse trace. Distances are in the unit of
+// the analysis: mouse counts, degrees or centimetres.
+type TraceMetrics struct {
+	Points      int     `json:"points"`
+	DurationSec float64 `json:"durationSec"`
+	PathLength  float64 `json:"pathLength"`
+	MeanSpeed   float64 `json:"meanSpeed"` // units per second
+	PeakSpeed   float64 `json:"peakSpeed"`
+	// DirectionChanges counts horizontal reversals, a rough measure of corrections.
+	DirectionChanges int `json:"directionChanges"`
+	// Displacement sums the straight-line distance of each movement (motion between
+	// stationary periods); PathEfficiency is Displacement over the distance moved.
+	Displacement   float64 `json:"displacement"`
+	PathEfficiency float64 `json:"pathEfficiency"`
+	Movements      int     `json:"movements"`
+	// Acceleration is in units/s² and jerk in units/s³, both as absolute values.
+	MeanAccel     float64 `json:"meanAccel"`
+	PeakAccel     float64 `json:"peakAccel"`
+	MeanJerk      float64 `json:"meanJerk"`
+	PeakJerk      float64 `json:"peakJerk"`
+	MovingSec     float64 `json:"movingSec"`
+	StationarySec float64 `json:"stationarySec"`
+}
+
+// TraceSlice is a time window of a run's mouse trace, downsampled for rendering.
+type TraceSlice struct {
+	RunID string `json:"runId"`
+	// Total is the number of samples in the whole trace and Matched the number in
+	// the window before downsampling.
+	Total       int     `json:"total"`
+	Matched     int     `json:"matched"`
+	DurationSec float64 `json:"durationSec"`
+	// FromSec and ToSec bound the window in seconds from the trace's first sample.
+	FromSec float64      `json:"fromSec"`
+	ToSec   float64      `json:"toSec"`
+	Points  []MousePoint `json:"points"`
+}
+
+// KinematicSample is the motion in one fixed-width time bin of a mouse trace.
+type KinematicSample struct {
+	T     float64 `json:"t"` // seconds from the first sample to the bin centre
+	Speed float64 `json:"speed"`
+	Accel float64 `json:"accel"`
+	Jerk  float64 `json:"jerk"`
+}
+
+// TraceKinematics is the motion analysis of a mouse trace, cached with the trace.
+type TraceKinematics struct {
+	// Version identifies the analysis; caches from an older version are recomputed.
+	Version int `json:"version"`
+	// Unit is what distances are measured in: counts, deg or cm.
+	Unit    string       `json:"unit"`
+	Metrics TraceMetrics `json:"metrics"`
+	// BinSec is the width of each Profile bin in seconds.
+	BinSec   float64           `json:"binSec"`
+	Profile  []KinematicSample `json:"profile"`
+	Spectrum TraceSpectrum     `json:"spectrum"`
+}
+
+// SpectrumBin is the velocity power density at one frequency, in units²/s² per Hz.
+type SpectrumBin struct {
+	Hz    float64 `json:"hz"`
+	Power float64 `json:"power"`
+}
+
+// TraceSpectrum is the spectral analysis of a mouse trace's velocity, averaged over
+// the windows in which the mouse moved.
+type TraceSpectrum struct {
+	SampleHz float64 `json:"sampleHz"`
+	Windows  int     `json:"windows"`
+	// The tremor band is BandLowHz–BandHighHz. TremorRMS is the velocity in it, in
+	// units/s, and TremorRatio its share of all velocity power above drift.
+	BandLowHz    float64 `json:"bandLowHz"`
+	BandHighHz   float64 `json:"bandHighHz"`
+	TremorRMS    float64 `json:"tremorRms"`
+	TremorRatio  float64 `json:"tremorRatio"`
+	PeakTremorHz float64 `json:"peakTremorHz"`
+	// Smoothness is the mean spectral arc length (SPARC) of the speed profile: 0 is
+	// perfectly smooth and more negative values are jerkier.
+	Smoothness float64       `json:"smoothness"`
+	PSD        []SpectrumBin `json:"psd"`
+}
+
+// SmoothnessPoint is the tremor and smoothness of one run with a recorded trace.
+type SmoothnessPoint struct {
+	RunID       string  `json:"runId"`
+	Played      string  `json:"played"` // RFC3339
+	Score       float64 `json:"score"`
+	TremorRatio float64 `json:"tremorRatio"`
+	Smoothness  float64 `json:"smoothness"`
+}
+
+// SmoothnessTrend tracks tremor and smoothness across a scenario's runs.
+type SmoothnessTrend struct {
+	Scenario string            `json:"scenario"`
+	Points   []SmoothnessPoint `json:"points"` // oldest first
+	// Slopes are per run; falling tremor and rising smoothness are improvements.
+	TremorSlope     float64 `json:"tremorSlope"`
+	SmoothnessSlope float64 `json:"smoothnessSlope"`
+}
+
+// RunRef identifies one side of a run comparison.
+type RunRef struct {
+	ID       string  `json:"id"`
+	Scenario string  `json:"scenario"`
+	Played   string  `json:"played"` // RFC3339
+	Score    float64 `json:"score"`
+}
+
+// StatDiff compares one numeric value between two runs.
+type StatDiff struct {
+	Key   string  `json:"key"`
+	A     float64 `json:"a"`
+	B     float64 `json:"b"`
+	Delta float64 `json:"delta"` // B - A
+	// Rel is B relative to A (0.05 = 5% higher); 0 when A is 0.
+	Rel float64 `json:"rel"`
+}
+
+// KillDelta aligns the n-th kill of two runs by time since each run started.
+type KillDelta struct {
+	Index int     `json:"index"` // 1-based kill number
+	A     float64 `json:"a"`     // seconds since run A started
+	B     float64 `json:"b"`
+	Delta float64 `json:"delta"` // B - A; positive means run B was behind
+}
+
+// TraceComparison compares the mouse traces of two runs.
+type TraceComparison struct {
+	// Unit is deg when both runs' sensitivities are known, so runs at different
+	// sensitivities compare fairly, and counts otherwise.
+	Unit  string       `json:"unit"`
+	A     TraceMetrics `json:"a"`
+	B     TraceMetrics `json:"b"`
+	Diffs []StatDiff   `json:"diffs"`
+}
+
+// RunComparison puts two runs side by side.
+type RunComparison struct {
+	A     RunRef      `json:"a"`
+	B     RunRef      `json:"b"`
+	Stats []StatDiff  `json:"stats"`
+	Kills []KillDelta `json:"kills"`
+	// Setup lists settings that differ; Before is run A's value and After run B's.
+	Setup []SetupChange `json:"setup"`
+	// Trace is set only when both runs have a mouse trace.
+	Trace *TraceComparison `json:"trace,omitempty"`
+}
+
+// AnomalyReason explains why a run was flagged: a technical stat outside the norm
+// of the runs played before it.
+type AnomalyReason struct {
+	// Code is one of fps_drop, time_dilation, input_lag, resolution_scale, target_scale.
+	Code  string  `json:"code"`
+	Stat  string  `json:"stat"`
+	Value float64 `json:"value"`
+	Norm  float64 `json:"norm"`
+}
+
+// RunAnomaly is a flagged run with its reasons.
+type RunAnomaly struct {
+	ID       string          `json:"id"`
+	Scenario string          `json:"scenario"`
+	Played   string          `json:"played"`
+	Score    float64         `json:"score"`
+	Reasons  []AnomalyReason `json:"reasons"`
+	// Excluded is set when anomalous runs are currently hidden by the settings.
+	Excluded bool `json:"excluded"`
+}
+
+// SetupEpoch is a stretch of runs played with the same setup.
+type SetupEpoch struct {
+	Index int    `json:"index"`
+	Start string `json:"start"` // RFC3339, first run
+	End   string `json:"end"`   // RFC3339, last run
+	Runs  int    `json:"runs"`
+	// Settings holds the setup values of the epoch by stats key.
+	Settings map[string]string `json:"settings"`
+}
+
+// SetupImpact compares one scenario's runs just before and just after a setup change.
+type SetupImpact struct {
+	Scenario   string  `json:"scenario"`
+	RunsBefore int     `json:"runsBefore"`
+	RunsAfter  int     `json:"runsAfter"`
+	MeanBefore float64 `json:"meanBefore"`
+	MeanAfter  float64 `json:"meanAfter"`
+	// Expected is the mean score the learning trend before the change predicts for
+	// the runs after it.
+	Expected float64 `json:"expected"`
+	// Effect is how far runs after the change sit above the trend, relative to the
+	// typical score before it.
+	Effect float64 `json:"effect"`
+	// EffectSize is Effect in units of the pooled run-to-run spread (Cohen's d).
+	EffectSize float64 `json:"effectSize"`
+	TStat      float64 `json:"tStat"`
+	// Confidence is "low", "med" or "high", derived from the T statistic of Effect.
+	Confidence string `json:"confidence"`
+}
+
+// SetupChangePoint is a confirmed setup change between two epochs.
+type SetupChangePoint struct {
+	At    string `json:"at"`    // RFC3339, first run with the new setup
+	Epoch int    `json:"epoch"` // index of the epoch that starts here
+	// Categories are the changed areas: sensitivity, fov, crosshair, resolution.
+	Categories []string      `json:"categories"`
+	Changes    []SetupChange `json:"changes"`
+	// Impacts are per scenario, strongest evidence first.
+	Impacts []SetupImpact `json:"impacts"`
+	// MeanEffect averages Effect over the scenarios with an impact.
+	MeanEffect float64 `json:"meanEffect"`
+}
+
+// SetupAnalysis lists setup epochs and the impact of each change, oldest first.
+type SetupAnalysis struct {
+	Epochs  []SetupEpoch       `json:"epochs"`
+	Changes []SetupChangePoint `json:"changes"`
+}
+
+// VersionSpan is a stretch of a scenario's runs played on one scenario hash or one
+// game version.
+type VersionSpan struct {
+	Value     string  `json:"value"`
+	First     string  `json:"first"` // RFC3339, first run
+	Last      string  `json:"last"`  // RFC3339, last run
+	Runs      int     `json:"runs"`
+	BestScore float64 `json:"bestScore"`
+	// Current marks the span of the scenario's latest run.
+	Current bool `json:"current"`
+}
+
+// VersionBoundary marks the first run of a scenario on a new scenario hash or game version.
+type VersionBoundary struct {
+	Scenario string `json:"scenario"`
+	// Kind is "hash" when the scenario was edited and "game" for a game patch.
+	Kind   string `json:"kind"`
+	RunID  string `json:"runId"`
+	At     string `json:"at"` // RFC3339
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// ScenarioLineage lists the scenario hashes and game versions a scenario was played on.
+type ScenarioLineage struct {
+	Scenario     string            `json:"scenario"`
+	Hashes       []VersionSpan     `json:"hashes"`
+	GameVersions []VersionSpan     `json:"gameVersions"`
+	Boundaries   []VersionBoundary `json:"boundaries"`
+}
+
+// Flick is the mouse movement from one kill (or the run start) to the next kill.
+// Distances are in the analysis unit; the target is taken to be where the mouse was
+// at the kill.
+type Flick struct {
+	Kill  int     `json:"kill"`  // 1-based kill number
+	Start float64 `json:"start"` // seconds from the run start
+	End   float64 `json:"end"`
+	// Distance is the straight line from the previous kill to this one, AngleDeg its
+	// direction (0 = right, 90 = up) and Direction the dominant axis as a word.
+	Distance  float64 `json:"distance"`
+	AngleDeg  float64 `json:"angleDeg"`
+	Direction string  `json:"direction"` // left, right, up or down
+	// Measured is false for flicks too short to analyse; only the fields above are set.
+	Measured    bool    `json:"measured"`
+	ReactionSec float64 `json:"reactionSec"` // until the mouse starts moving
+	PeakSpeed   float64 `json:"peakSpeed"`   // units per second
+	// Overshoot is how far the mouse went past the target and Undershoot how far
+	// short the first movement stopped, both relative to Distance.
+	Overshoot   float64 `json:"overshoot"`
+	Undershoot  float64 `json:"undershoot"`
+	Corrections int     `json:"corrections"` // movements after the first one
+	// SettleSec is the time from movement onset until the mouse stays near the target.
+	SettleSec float64 `json:"settleSec"`
+}
+
+// FlickGroup averages measured flicks of one direction and distance band.
+type FlickGroup struct {
+	Direction       string  `json:"direction"`
+	Band            string  `json:"band"` // "short" or "long", split at the median distance
+	Flicks          int     `json:"flicks"`
+	MeanDistance    float64 `json:"meanDistance"`
+	MeanReactionSec float64 `json:"meanReactionSec"`
+	MeanPeakSpeed   float64 `json:"meanPeakSpeed"`
+	MeanOvershoot   float64 `json:"meanOvershoot"`
+	MeanUndershoot  float64 `json:"meanUndershoot"`
+	MeanCorrections float64 `json:"meanCorrections"`
+	MeanSettleSec   float64 `json:"meanSettleSec"`
+}
+
+// FlickAnalysis breaks a run's mouse trace into per-kill flicks.
+type FlickAnalysis struct {
+	RunID string `json:"runId"`
+	// Unit is what distances and speeds are measured in: counts, deg or cm.
+	Unit   string       `json:"unit"`
+	Flicks []Flick      `json:"flicks"`
+	Groups []FlickGroup `json:"groups"`
+}
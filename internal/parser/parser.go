@@ -15,15 +15,18 @@ import (
 )
 
 var (
-	// Example: "Air Tracking 180 - Challenge - 2025.09.09-16.57.00 Stats.csv"
-	filenameRe = regexp.MustCompile(`^(?P<name>.+?)\s-\s.*?-\s(?P<dt>\d{4}\.\d{2}\.\d{2}-\d{2}\.\d{2}\.\d{2})\sStats\.csv$`)
+	// Example: "Air Tracking 180 - Challenge - 2025.09.09-16.57.00 Stats.csv". The mode
+	// segment is optional.
+	filenameRe = regexp.MustCompile(`^(?P<name>.+?)\s-\s(?:(?P<mode>.*?)-\s)?(?P<dt>\d{4}\.\d{2}\.\d{2}-\d{2}\.\d{2}\.\d{2})\sStats\.csv$`)
 	dtLayout   = "2006.01.02-15.04.05"
 )
 
 // FilenameInfo represents parsed info from a stats filename.
 type FilenameInfo struct {
 	ScenarioName string
-	DatePlayed   time.Time
+	// Mode is the play mode segment of the filename, e.g. "Challenge" or "Freeplay".
+	Mode       string
+	DatePlayed time.Time
 }
 
 // ParseFilename extracts scenario name and timestamp from a Kovaak's stats filename.
@@ -34,12 +37,17 @@ func ParseFilename(filename string) (FilenameInfo, error) {
 		return FilenameInfo{}, fmt.Errorf("filename did not match expected format: %s", base)
 	}
 	name := m[1]
-	dtStr := m[2]
+	// The mode is the segment right before the timestamp, even if the name contains " - ".
+	mode := strings.TrimSpace(m[2])
+	if i := strings.LastIndex(mode, " - "); i >= 0 {
+		mode = mode[i+3:]
+	}
+	dtStr := m[3]
 	t, err := time.ParseInLocation(dtLayout, dtStr, time.Local)
 	if err != nil {
 		return FilenameInfo{}, err
 	}
-	return FilenameInfo{ScenarioName: name, DatePlayed: t}, nil
+	return FilenameInfo{ScenarioName: name, Mode: mode, DatePlayed: t}, nil
 }
 
 // ParseStatsFile parses a Kovaak's CSV stats file into events and stats map.
@@ -50,8 +58,13 @@ func ParseStatsFile(path string) (events [][]string, stats map[string]any, err e
 		return nil, nil, err
 	}
 	defer f.Close()
+	return ParseStats(f)
+}
 
-	wrapped, werr := WrapReaderWithUTF8(f)
+// ParseStats parses Kovaak's stats content from an arbitrary reader (e.g., a file
+// inside a compressed archive). See ParseStatsFile for the expected format.
+func ParseStats(src io.Reader) (events [][]string, stats map[string]any, err error) {
+	wrapped, werr := WrapReaderWithUTF8(src)
 	if werr != nil {
 		return nil, nil, werr
 	}
@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseFilename(t *testing.T) {
+	cases := []struct {
+		file, name, mode string
+		played           time.Time
+		err              bool
+	}{
+		{
+			file: "Air Tracking 180 - Challenge - 2025.09.09-16.57.00 Stats.csv",
+			name: "Air Tracking 180", mode: "Challenge",
+			played: time.Date(2025, 9, 9, 16, 57, 0, 0, time.Local),
+		},
+		{
+			file: "1w4ts reload - Freeplay - 2025.10.02-18.36.37 Stats.csv",
+			name: "1w4ts reload", mode: "Freeplay",
+			played: time.Date(2025, 10, 2, 18, 36, 37, 0, time.Local),
+		},
+		{
+			// Paths are reduced to the base name.
+			file: "/stats/VT Pasu Intermediate S5 - Challenge - 2025.10.26-13.12.08 Stats.csv",
+			name: "VT Pasu Intermediate S5", mode: "Challenge",
+			played: time.Date(2025, 10, 26, 13, 12, 8, 0, time.Local),
+		},
+		{
+			// The mode is the last segment before the timestamp.
+			file: "Pasu - Voltaic - Challenge - 2025.10.26-13.12.08 Stats.csv",
+			name: "Pasu", mode: "Challenge",
+			played: time.Date(2025, 10, 26, 13, 12, 8, 0, time.Local),
+		},
+		{
+			file: "Close Long Strafes - 2025.01.05-09.00.00 Stats.csv",
+			name: "Close Long Strafes", mode: "",
+			played: time.Date(2025, 1, 5, 9, 0, 0, 0, time.Local),
+		},
+		{file: "Air Tracking 180 - Challenge - 2025.09.09-16.57.00.csv", err: true},
+		{file: "notes.txt", err: true},
+		{file: "Air - Challenge - 2025.13.40-16.57.00 Stats.csv", err: true},
+	}
+	for _, c := range cases {
+		info, err := ParseFilename(c.file)
+		if c.err {
+			if err == nil {
+				t.Errorf("%q: expected an error, got %+v", c.file, info)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", c.file, err)
+			continue
+		}
+		if info.ScenarioName != c.name || info.Mode != c.mode || !info.DatePlayed.Equal(c.played) {
+			t.Errorf("%q: got %q / %q / %v, want %q / %q / %v", c.file, info.ScenarioName, info.Mode, info.DatePlayed, c.name, c.mode, c.played)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package pb
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"refleks/internal/models"
+)
+
+// EventName is emitted by the watcher when a live run sets a new personal best.
+const EventName = "PersonalBest"
+
+// Run is the per-run input for personal best tracking.
+type Run struct {
+	ID       string // stats file name
+	Scenario string
+	Played   time.Time
+	Score    float64
+}
+
+// Ledger keeps the highscore progression of every scenario. Each timeline only ever
+// contains strictly increasing scores, oldest first. It is safe for concurrent use.
+type Ledger struct {
+	mu        sync.RWMutex
+	timelines map[string][]models.PersonalBest
+	// runs keeps the inputs per scenario so a late, older run can be replayed in order.
+	runs map[string][]Run
+}
+
+// NewLedger returns an empty ledger.
+func NewLedger() *Ledger {
+	return &Ledger{timelines: make(map[string][]models.PersonalBest), runs: make(map[string][]Run)}
+}
+
+// Rebuild replaces all state with timelines computed from runs.
+func (l *Ledger) Rebuild(runs []Run) {
+	byScenario := make(map[string][]Run)
+	for _, r := range runs {
+		byScenario[r.Scenario] = append(byScenario[r.Scenario], r)
+	}
+	timelines := make(map[string][]models.PersonalBest, len(byScenario))
+	for name, rs := range byScenario {
+		sortRuns(rs)
+		timelines[name] = timeline(rs)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.runs = byScenario
+	l.timelines = timelines
+}
+
+// Add records a run. It returns the resulting PB and true only when the run is the
+// newest for its scenario and beats an earlier best; first runs and backfilled older
+// runs update the timeline silently.
+func (l *Ledger) Add(r Run) (models.PersonalBest, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	rs := l.runs[r.Scenario]
+	for _, existing := range rs {
+		if existing.ID == r.ID {
+			return models.PersonalBest{}, false
+		}
+	}
+	if n := len(rs); n > 0 && r.Played.Before(rs[n-1].Played) {
+		rs = append(rs, r)
+		sortRuns(rs)
+		l.runs[r.Scenario] = rs
+		l.timelines[r.Scenario] = timeline(rs)
+		return models.PersonalBest{}, false
+	}
+	l.runs[r.Scenario] = append(rs, r)
+	tl := l.timelines[r.Scenario]
+	if n := len(tl); n > 0 && r.Score <= tl[n-1].Score {
+		return models.PersonalBest{}, false
+	}
+	var prev *models.PersonalBest
+	if n := len(tl); n > 0 {
+		prev = &tl[n-1]
+	}
+	p := entry(r, prev)
+	l.timelines[r.Scenario] = append(tl, p)
+	return p, !p.First
+}
+
+// Timeline returns the PB progression of one scenario, oldest first.
+func (l *Ledger) Timeline(scenario string) []models.PersonalBest {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return append([]models.PersonalBest(nil), l.timelines[scenario]...)
+}
+
+// Best returns the current PB of one scenario.
+func (l *Ledger) Best(scenario string) (models.PersonalBest, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	tl := l.timelines[scenario]
+	if len(tl) == 0 {
+		return models.PersonalBest{}, false
+	}
+	return tl[len(tl)-1], true
+}
+
+// Bests returns the current PB of every scenario, sorted by scenario name.
+func (l *Ledger) Bests() []models.PersonalBest {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	out := make([]models.PersonalBest, 0, len(l.timelines))
+	for _, tl := range l.timelines {
+		if len(tl) > 0 {
+			out = append(out, tl[len(tl)-1])
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Scenario < out[j].Scenario })
+	return out
+}
+
+func sortRuns(rs []Run) {
+	sort.SliceStable(rs, func(i, j int) bool { return rs[i].Played.Before(rs[j].Played) })
+}
+
+// timeline walks runs oldest first and keeps each one that raises the best.
+func timeline(rs []Run) []models.PersonalBest {
+	var out []models.PersonalBest
+	for _, r := range rs {
+		n := len(out)
+		if n > 0 && r.Score <= out[n-1].Score {
+			continue
+		}
+		var prev *models.PersonalBest
+		if n > 0 {
+			prev = &out[n-1]
+		}
+		out = append(out, entry(r, prev))
+	}
+	return out
+}
+
+func entry(r Run, prev *models.PersonalBest) models.PersonalBest {
+	p := models.PersonalBest{
+		Scenario:   r.Scenario,
+		RunID:      r.ID,
+		DatePlayed: r.Played.Format(time.RFC3339),
+		Score:      r.Score,
+	}
+	if prev == nil {
+		p.First = true
+		return p
+	}
+	p.PreviousScore = prev.Score
+	p.Margin = r.Score - prev.Score
+	if prev.Score != 0 {
+		p.MarginPct = p.Margin / prev.Score * 100
+	}
+	return p
+}
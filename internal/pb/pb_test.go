@@ -0,0 +1,34 @@
+package pb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLedger(t *testing.T) {
+	base := time.Date(2025, 10, 2, 18, 0, 0, 0, time.Local)
+	run := func(id string, min int, score float64) Run {
+		return Run{ID: id, Scenario: "VT Ground Intermediate S5", Played: base.Add(time.Duration(min) * time.Minute), Score: score}
+	}
+	l := NewLedger()
+	l.Rebuild([]Run{run("b", 2, 90), run("a", 1, 100), run("c", 3, 110)})
+
+	if _, ok := l.Add(run("d", 4, 105)); ok {
+		t.Fatalf("non-PB run reported as PB")
+	}
+	p, ok := l.Add(run("e", 5, 121))
+	if !ok || p.PreviousScore != 110 || p.Margin != 11 || p.MarginPct != 10 {
+		t.Fatalf("unexpected PB: %+v ok=%v", p, ok)
+	}
+	// A backfilled older run is folded in without an event.
+	if _, ok := l.Add(run("x", 0, 130)); ok {
+		t.Fatalf("backfilled run reported as PB")
+	}
+	tl := l.Timeline("VT Ground Intermediate S5")
+	if len(tl) != 1 || tl[0].RunID != "x" || !tl[0].First {
+		t.Fatalf("unexpected timeline after backfill: %+v", tl)
+	}
+	if _, ok := l.Add(run("e", 5, 121)); ok {
+		t.Fatalf("duplicate run reported as PB")
+	}
+}
@@ -0,0 +1,164 @@
+package rating
+
+import (
+	"sort"
+	"time"
+
+	"refleks/internal/benchmarks"
+	"refleks/internal/history"
+	"refleks/internal/models"
+	"refleks/internal/scenariotype"
+)
+
+const (
+	// baselineRuns is how many of a scenario's previous runs a run is ranked against.
+	baselineRuns = 100
+	// minBaseline is the fewest previous runs before a run is rated.
+	minBaseline = 5
+	// span is the EWMA span in runs; recent runs dominate, older form fades out.
+	span = 30
+)
+
+const alpha = 2.0 / (span + 1)
+
+// Performance is one run normalized against the player's history and, when known,
+// the benchmark thresholds of its scenario.
+type Performance struct {
+	RunID    string
+	Scenario string
+	Category string
+	Played   time.Time
+	// Percentile is the run's mid-rank among the scenario's previous baselineRuns runs.
+	Percentile float64
+	// RankProgress is the position within the benchmark ranks, or -1 when unknown.
+	RankProgress float64
+}
+
+// Performances normalizes every run with enough history, oldest first.
+func Performances(runs []history.Run, index map[string]benchmarks.IndexEntry) []Performance {
+	sorted := append([]history.Run(nil), runs...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].End.Before(sorted[j].End) })
+	prev := make(map[string][]float64)
+	var out []Performance
+	for _, r := range sorted {
+		past := prev[r.Scenario]
+		prev[r.Scenario] = append(past, r.Score)
+		if len(past) < minBaseline {
+			continue
+		}
+		if len(past) > baselineRuns {
+			past = past[len(past)-baselineRuns:]
+		}
+		var below, equal int
+		for _, s := range past {
+			switch {
+			case s < r.Score:
+				below++
+			case s == r.Score:
+				equal++
+			}
+		}
+		p := Performance{
+			RunID:        r.ID,
+			Scenario:     r.Scenario,
+			Played:       r.End,
+			Category:     Category(r.Scenario, index),
+			Percentile:   (float64(below) + 0.5*float64(equal)) / float64(len(past)),
+			RankProgress: -1,
+		}
+		if e, ok := index[r.Scenario]; ok && len(e.Thresholds) > 0 {
+			p.RankProgress = RankProgress(r.Score, e.Thresholds)
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// Category returns a scenario's category: its benchmark category where known, else a
+// guess from the name. Benchmark categories such as "Reactive Tracking" are folded
+// into the base types so ratings are comparable across benchmarks.
+func Category(scenario string, index map[string]benchmarks.IndexEntry) string {
+	if e, ok := index[scenario]; ok {
+		if kind := scenariotype.Classify(e.Category); kind != scenariotype.Other {
+			return kind
+		}
+	}
+	return scenariotype.Classify(scenario)
+}
+
+// RankProgress places score on the rank ladder: rank r plus the fraction of the way to
+// rank r+1, from 0 below the first threshold up to len(thresholds) at the top rank.
+func RankProgress(score float64, thresholds []float64) float64 {
+	n := len(thresholds)
+	r := 0
+	for r < n && score >= thresholds[r] {
+		r++
+	}
+	switch {
+	case r == 0:
+		if thresholds[0] <= 0 {
+			return 0
+		}
+		return max(0, score/thresholds[0])
+	case r == n:
+		return float64(n)
+	}
+	lo, hi := thresholds[r-1], thresholds[r]
+	if hi <= lo {
+		return float64(r)
+	}
+	return float64(r) + (score-lo)/(hi-lo)
+}
+
+type state struct {
+	rating, rank float64
+	runs, ranked int
+	scenarios    map[string]struct{}
+	history      []models.RatingPoint
+}
+
+// Analyze rates every category from the runs, sorted by category name, with one
+// history point per local day the category was played.
+func Analyze(runs []history.Run, index map[string]benchmarks.IndexEntry) []models.CategoryRating {
+	states := make(map[string]*state)
+	for _, p := range Performances(runs, index) {
+		st, ok := states[p.Category]
+		if !ok {
+			st = &state{rating: 50, scenarios: make(map[string]struct{})}
+			states[p.Category] = st
+		}
+		st.rating += alpha * (100*p.Percentile - st.rating)
+		st.runs++
+		st.scenarios[p.Scenario] = struct{}{}
+		if p.RankProgress >= 0 {
+			if st.ranked == 0 {
+				st.rank = p.RankProgress
+			} else {
+				st.rank += alpha * (p.RankProgress - st.rank)
+			}
+			st.ranked++
+		}
+		day := p.Played.Local().Format("2006-01-02")
+		if n := len(st.history); n > 0 && st.history[n-1].Date == day {
+			st.history[n-1].Rating = st.rating
+			st.history[n-1].RankProgress = st.rank
+			st.history[n-1].Runs++
+		} else {
+			st.history = append(st.history, models.RatingPoint{Date: day, Rating: st.rating, RankProgress: st.rank, Runs: 1})
+		}
+	}
+	out := make([]models.CategoryRating, 0, len(states))
+	for name, st := range states {
+		out = append(out, models.CategoryRating{
+			Category:     name,
+			Rating:       st.rating,
+			RankProgress: st.rank,
+			Runs:         st.runs,
+			RankedRuns:   st.ranked,
+			Scenarios:    len(st.scenarios),
+			History:      st.history,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Category < out[j].Category })
+	return out
+}
@@ -0,0 +1,60 @@
+package rating
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"refleks/internal/benchmarks"
+	"refleks/internal/history"
+)
+
+func TestRankProgress(t *testing.T) {
+	th := []float64{100, 200, 400}
+	cases := []struct{ score, want float64 }{
+		{50, 0.5},
+		{100, 1},
+		{150, 1.5},
+		{300, 2.5},
+		{500, 3},
+	}
+	for _, c := range cases {
+		if got := RankProgress(c.score, th); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("RankProgress(%v) = %v, want %v", c.score, got, c.want)
+		}
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	start := time.Date(2025, 9, 1, 18, 0, 0, 0, time.Local)
+	var runs []history.Run
+	add := func(scenario string, day, i int, score float64) {
+		end := start.AddDate(0, 0, day).Add(time.Duration(i) * time.Minute)
+		runs = append(runs, history.Run{ID: fmt.Sprintf("%s-%d-%d", scenario, day, i), Scenario: scenario, Start: end.Add(-time.Minute), End: end, Score: score})
+	}
+	// Tracking improves steadily; clicking stays flat.
+	for d := 0; d < 10; d++ {
+		for i := 0; i < 5; i++ {
+			add("Smoothbot Goated", d, i, 1000+float64(d*50+i))
+			add("1wall 6targets small", d, 10+i, 1000+float64(i%2))
+		}
+	}
+	index := map[string]benchmarks.IndexEntry{
+		"Smoothbot Goated": {Category: "Reactive Tracking", Thresholds: []float64{900, 1200, 1500}},
+	}
+	got := Analyze(runs, index)
+	if len(got) != 2 || got[0].Category != "Clicking" || got[1].Category != "Tracking" {
+		t.Fatalf("unexpected categories %+v", got)
+	}
+	click, track := got[0], got[1]
+	if track.Rating < 80 || click.Rating > 70 {
+		t.Errorf("improving tracking should rate above flat clicking: tracking %.1f, clicking %.1f", track.Rating, click.Rating)
+	}
+	if click.RankedRuns != 0 || track.RankedRuns != track.Runs || track.RankProgress < 2 || track.RankProgress > 3 {
+		t.Errorf("rank progress should come from tracking thresholds only: %+v / %+v", click, track)
+	}
+	if len(track.History) != 9 || track.History[0].Date != "2025-09-02" {
+		t.Errorf("expected one history point per day once rated, got %d starting %v", len(track.History), track.History[0].Date)
+	}
+}
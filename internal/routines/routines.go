@@ -0,0 +1,290 @@
+package routines
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"refleks/internal/constants"
+	"refleks/internal/history"
+	"refleks/internal/models"
+	appsettings "refleks/internal/settings"
+	"refleks/internal/util"
+)
+
+// Events emitted by the watcher when a run counts towards today's routine.
+const (
+	EventProgress  = "RoutineProgress"
+	EventCompleted = "RoutineCompleted"
+)
+
+// adherenceDays is the window the adherence share is computed over.
+const adherenceDays = 28
+
+// Validate normalizes a routine and reports invalid fields.
+func Validate(r models.Routine) (models.Routine, error) {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return r, errors.New("routine needs a name")
+	}
+	items := make([]models.RoutineItem, 0, len(r.Items))
+	for i, it := range r.Items {
+		it.Scenario = strings.TrimSpace(it.Scenario)
+		if it.Scenario == "" {
+			return r, fmt.Errorf("item %d: missing scenario", i+1)
+		}
+		if it.Runs <= 0 {
+			return r, fmt.Errorf("item %d: runs must be positive", i+1)
+		}
+		items = append(items, it)
+	}
+	if len(items) == 0 {
+		return r, errors.New("routine needs at least one scenario")
+	}
+	r.Items = items
+	seen := make(map[int]bool)
+	days := make([]int, 0, len(r.Days))
+	for _, d := range r.Days {
+		if d < 0 || d > 6 {
+			return r, fmt.Errorf("invalid weekday %d", d)
+		}
+		if !seen[d] {
+			seen[d] = true
+			days = append(days, d)
+		}
+	}
+	sort.Ints(days)
+	r.Days = days
+	return r, nil
+}
+
+// Scheduled reports whether the routine is due on day's weekday.
+func Scheduled(r models.Routine, day time.Time) bool {
+	if len(r.Days) == 0 {
+		return true
+	}
+	wd := day.Local().Weekday()
+	for _, d := range r.Days {
+		if time.Weekday(d) == wd {
+			return true
+		}
+	}
+	return false
+}
+
+// dayKey is a run's local calendar day.
+func dayKey(t time.Time) string { return t.Local().Format("2006-01-02") }
+
+// progress counts the runs of one day against the routine's items. Runs of a
+// scenario listed twice fill the items in order.
+func progress(r models.Routine, counts map[string]int) ([]models.RoutineItemProgress, float64, string) {
+	left := make(map[string]int, len(counts))
+	for k, v := range counts {
+		left[k] = v
+	}
+	items := make([]models.RoutineItemProgress, len(r.Items))
+	var done, total int
+	next := ""
+	for i, it := range r.Items {
+		n := min(it.Runs, left[it.Scenario])
+		left[it.Scenario] -= n
+		items[i] = models.RoutineItemProgress{Scenario: it.Scenario, Target: it.Runs, Done: n}
+		done += n
+		total += it.Runs
+		if n < it.Runs && next == "" {
+			next = it.Scenario
+		}
+	}
+	return items, float64(done) / float64(total), next
+}
+
+// Evaluate matches runs against today's routine and computes adherence since the
+// routine was created. Runs may cover any period; only days since creation count.
+func Evaluate(r models.Routine, runs []history.Run, now time.Time) models.RoutineStatus {
+	want := make(map[string]bool, len(r.Items))
+	for _, it := range r.Items {
+		want[it.Scenario] = true
+	}
+	byDay := make(map[string]map[string]int)
+	for _, run := range runs {
+		if !want[run.Scenario] {
+			continue
+		}
+		k := dayKey(run.End)
+		if byDay[k] == nil {
+			byDay[k] = make(map[string]int)
+		}
+		byDay[k][run.Scenario]++
+	}
+	st := models.RoutineStatus{Routine: r, ScheduledToday: Scheduled(r, now)}
+	st.Items, st.Progress, st.NextScenario = progress(r, byDay[dayKey(now)])
+	st.Completed = st.Progress >= 1
+	// Progress still shows on off days, but there is nothing to play next.
+	if !st.ScheduledToday {
+		st.NextScenario = ""
+	}
+
+	created, err := time.Parse(time.RFC3339, r.CreatedAt)
+	if err != nil {
+		created = now
+	}
+	first := util.StartOfDay(created)
+	today := util.StartOfDay(now)
+	window := today.AddDate(0, 0, -adherenceDays)
+	var scheduled, completed, streak int
+	for day := first; !day.After(today); day = day.AddDate(0, 0, 1) {
+		if !Scheduled(r, day) {
+			continue
+		}
+		_, p, _ := progress(r, byDay[dayKey(day)])
+		done := p >= 1
+		// Today only counts once it is done, so an unfinished day does not break the streak.
+		if day.Equal(today) && !done {
+			continue
+		}
+		if done {
+			streak++
+			st.BestStreak = max(st.BestStreak, streak)
+		} else {
+			streak = 0
+		}
+		if day.After(window) {
+			scheduled++
+			if done {
+				completed++
+			}
+		}
+	}
+	st.Streak = streak
+	if scheduled > 0 {
+		st.Adherence = float64(completed) / float64(scheduled)
+	}
+	return st
+}
+
+// Store keeps routines in a JSON file in the app config directory.
+// It is safe for concurrent use.
+type Store struct {
+	mu       sync.Mutex
+	path     string
+	routines []models.Routine
+	// loadErr is why routines.json could not be loaded; changes are refused while it
+	// is set so saved routines are not overwritten.
+	loadErr error
+}
+
+// Open loads saved routines from routines.json in the app config directory. When
+// that fails for any reason other than a missing file, the error is returned with
+// an empty store that refuses changes, so the file on disk stays as it was.
+func Open() (*Store, error) {
+	s := &Store{}
+	base, err := appsettings.ConfigBaseDir()
+	if err != nil {
+		s.loadErr = fmt.Errorf("routines unavailable: %w", err)
+		return s, s.loadErr
+	}
+	s.path = filepath.Join(base, constants.RoutinesFileName)
+	if err := util.LoadJSON(s.path, &s.routines); err != nil {
+		s.routines = nil
+		s.loadErr = fmt.Errorf("%s could not be read, fix or remove it to edit routines: %w", s.path, err)
+		return s, s.loadErr
+	}
+	return s, nil
+}
+
+// NewMemory returns a store without persistence.
+func NewMemory() *Store {
+	return &Store{}
+}
+
+// Err reports why the saved routines could not be loaded, or nil.
+func (s *Store) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loadErr
+}
+
+// List returns every routine, oldest first.
+func (s *Store) List() []models.Routine {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]models.Routine(nil), s.routines...)
+}
+
+// Get returns the routine with the given ID.
+func (s *Store) Get(id string) (models.Routine, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.routines {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return models.Routine{}, false
+}
+
+// Add validates and stores a new routine, assigning its ID and creation time.
+func (s *Store) Add(r models.Routine, now time.Time) (models.Routine, error) {
+	r, err := Validate(r)
+	if err != nil {
+		return r, err
+	}
+	r.ID = strconv.FormatInt(now.UnixNano(), 36)
+	r.CreatedAt = now.Format(time.RFC3339)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loadErr != nil {
+		return models.Routine{}, s.loadErr
+	}
+	s.routines = append(s.routines, r)
+	return r, s.saveLocked()
+}
+
+// Update replaces an existing routine's name, items and schedule.
+func (s *Store) Update(r models.Routine) error {
+	r, err := Validate(r)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loadErr != nil {
+		return s.loadErr
+	}
+	for i, old := range s.routines {
+		if old.ID == r.ID {
+			r.CreatedAt = old.CreatedAt
+			s.routines[i] = r
+			return s.saveLocked()
+		}
+	}
+	return fmt.Errorf("routine %q not found", r.ID)
+}
+
+// Delete removes a routine.
+func (s *Store) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loadErr != nil {
+		return s.loadErr
+	}
+	for i, r := range s.routines {
+		if r.ID == id {
+			s.routines = append(s.routines[:i], s.routines[i+1:]...)
+			return s.saveLocked()
+		}
+	}
+	return fmt.Errorf("routine %q not found", id)
+}
+
+func (s *Store) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	return util.SaveJSON(s.path, s.routines)
+}
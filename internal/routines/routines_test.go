@@ -0,0 +1,121 @@
+package routines
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"refleks/internal/constants"
+	"refleks/internal/history"
+	"refleks/internal/models"
+	appsettings "refleks/internal/settings"
+)
+
+func TestEvaluate(t *testing.T) {
+	// Wednesday 1 October 2025; the routine runs Monday to Friday.
+	now := time.Date(2025, 10, 1, 20, 0, 0, 0, time.Local)
+	r := models.Routine{
+		Name:      "Daily",
+		Items:     []models.RoutineItem{{Scenario: "A", Runs: 2}, {Scenario: "B", Runs: 1}},
+		Days:      []int{1, 2, 3, 4, 5},
+		CreatedAt: time.Date(2025, 9, 22, 9, 0, 0, 0, time.Local).Format(time.RFC3339),
+	}
+	var runs []history.Run
+	play := func(day time.Time, scenario string, n int) {
+		for i := 0; i < n; i++ {
+			end := day.Add(time.Duration(len(runs)) * time.Minute)
+			runs = append(runs, history.Run{ID: fmt.Sprint(len(runs)), Scenario: scenario, Start: end.Add(-time.Minute), End: end})
+		}
+	}
+	at := func(day int) time.Time { return time.Date(2025, 9, day, 18, 0, 0, 0, time.Local) }
+	// Week of 22 Sep: Mon-Thu done, Friday only half done, weekend skipped.
+	for _, d := range []int{22, 23, 24, 25} {
+		play(at(d), "A", 2)
+		play(at(d), "B", 1)
+	}
+	play(at(26), "A", 2)
+	// Monday and Tuesday done, then one run of A today.
+	for _, d := range []int{29, 30} {
+		play(at(d), "B", 1)
+		play(at(d), "A", 3)
+	}
+	play(time.Date(2025, 10, 1, 18, 0, 0, 0, time.Local), "A", 1)
+
+	st := Evaluate(r, runs, now)
+	if !st.ScheduledToday || st.Completed || st.NextScenario != "A" {
+		t.Fatalf("unexpected today status %+v", st)
+	}
+	if st.Items[0].Done != 1 || st.Items[1].Done != 0 || st.Progress != 1.0/3 {
+		t.Errorf("unexpected item progress %+v (%.2f)", st.Items, st.Progress)
+	}
+	if st.Streak != 2 || st.BestStreak != 4 {
+		t.Errorf("streak = %d best %d, want 2 and 4", st.Streak, st.BestStreak)
+	}
+	if st.Adherence != 6.0/7 {
+		t.Errorf("adherence = %v, want 6/7", st.Adherence)
+	}
+
+	play(time.Date(2025, 10, 1, 19, 0, 0, 0, time.Local), "A", 1)
+	play(time.Date(2025, 10, 1, 19, 0, 0, 0, time.Local), "B", 1)
+	if st := Evaluate(r, runs, now); !st.Completed || st.NextScenario != "" || st.Streak != 3 {
+		t.Errorf("expected today completed with a streak of 3, got %+v", st)
+	}
+}
+
+func TestEvaluateUnscheduledDay(t *testing.T) {
+	// Saturday 4 October 2025 with a weekday-only routine.
+	now := time.Date(2025, 10, 4, 12, 0, 0, 0, time.Local)
+	r := models.Routine{
+		Name:      "Weekdays",
+		Items:     []models.RoutineItem{{Scenario: "A", Runs: 2}},
+		Days:      []int{1, 2, 3, 4, 5},
+		CreatedAt: time.Date(2025, 10, 4, 9, 0, 0, 0, time.Local).Format(time.RFC3339),
+	}
+	runs := []history.Run{{ID: "1", Scenario: "A", Start: now.Add(-2 * time.Minute), End: now.Add(-time.Minute)}}
+
+	st := Evaluate(r, runs, now)
+	if st.ScheduledToday || st.NextScenario != "" {
+		t.Fatalf("an off day should have nothing to play next, got %+v", st)
+	}
+	if st.Items[0].Done != 1 || st.Progress != 0.5 {
+		t.Errorf("progress should still count off-day runs, got %+v (%.2f)", st.Items, st.Progress)
+	}
+	if st.Streak != 0 || st.Adherence != 0 {
+		t.Errorf("off days must not count toward streak or adherence, got %d and %v", st.Streak, st.Adherence)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if _, err := Validate(models.Routine{Name: "x"}); err == nil {
+		t.Error("a routine without scenarios should be rejected")
+	}
+	r, err := Validate(models.Routine{Name: " x ", Items: []models.RoutineItem{{Scenario: "A", Runs: 1}}, Days: []int{5, 1, 5}})
+	if err != nil || r.Name != "x" || len(r.Days) != 2 || r.Days[0] != 1 {
+		t.Errorf("unexpected normalization %+v (%v)", r, err)
+	}
+}
+
+func TestOpenUnreadableFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	base, err := appsettings.ConfigBaseDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(base, constants.RoutinesFileName)
+	const broken = `{"routines": [`
+	if err := os.WriteFile(path, []byte(broken), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Open()
+	if err == nil || s.Err() == nil {
+		t.Fatal("expected the parse error to be reported")
+	}
+	if _, err := s.Add(models.Routine{Name: "x", Items: []models.RoutineItem{{Scenario: "A", Runs: 1}}}, time.Now()); err == nil {
+		t.Error("adding a routine should fail while routines.json is unreadable")
+	}
+	if b, _ := os.ReadFile(path); string(b) != broken {
+		t.Errorf("routines.json was rewritten: %q", b)
+	}
+}
@@ -0,0 +1,43 @@
+package scenariotype
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Scenario types, named after the benchmark categories.
+const (
+	Clicking  = "Clicking"
+	Tracking  = "Tracking"
+	Switching = "Switching"
+	Other     = "Other"
+)
+
+// Keywords are matched as prefixes of lower-case name tokens, in this order, so a
+// "smooth switch" scenario is a switching one and "tracking" matches "track".
+var keywords = []struct {
+	kind     string
+	prefixes []string
+}{
+	{Switching, []string{"switch", "domi", "pokeball", "bounce"}},
+	{Tracking, []string{"track", "smooth", "ground", "air", "strafe", "control", "whisphere", "centering", "sine", "reactive", "adad"}},
+	{Clicking, []string{"click", "1w", "ww", "pasu", "popcorn", "tile", "frenzy", "flick", "micro", "static", "reflex", "dot", "burst", "sixshot"}},
+}
+
+// Classify guesses a scenario's type from its name. Kovaak's stats files carry no
+// category, so this is a best-effort heuristic; unknown names map to Other.
+func Classify(name string) string {
+	tokens := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, k := range keywords {
+		for _, tok := range tokens {
+			for _, p := range k.prefixes {
+				if strings.HasPrefix(tok, p) {
+					return k.kind
+				}
+			}
+		}
+	}
+	return Other
+}
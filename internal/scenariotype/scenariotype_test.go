@@ -0,0 +1,29 @@
+package scenariotype
+
+import "testing"
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"VT Ground Intermediate S5", Tracking},
+		{"Air Angelic 4 Voltaic", Tracking},
+		{"Controlsphere", Tracking},
+		{"Close Long Strafes Invincible", Tracking},
+		{"VT 1w3ts Intermediate S5", Clicking},
+		{"Pasu Voltaic Easy", Clicking},
+		{"PopcornMicro", Clicking},
+		{"1wall 6targets small", Clicking},
+		{"VT Pokeball Frenzy Intermediate S5", Switching},
+		{"Smooth Switch", Switching},
+		{"Domiswitch Hard", Switching},
+		{"Thin Gauntlet", Other},
+		{"", Other},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.name); got != tt.want {
+			t.Errorf("Classify(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package sens
+
+import (
+	"refleks/internal/models"
+	"refleks/internal/util"
+)
+
+// Units a mouse trace can be expressed in.
+const (
+	UnitCounts      = "counts"
+	UnitDegrees     = "deg"
+	UnitCentimetres = "cm"
+)
+
+// Scale converts mouse counts into a unit, separately per axis. In degrees, X is
+// yaw and Y is pitch.
+type Scale struct {
+	Unit string
+	X, Y float64 // units per count
+}
+
+// Counts keeps a trace in raw mouse counts.
+var Counts = Scale{Unit: UnitCounts, X: 1, Y: 1}
+
+// Mean is the average per-count factor of both axes, for converting thresholds.
+func (s Scale) Mean() float64 {
+	return (s.X + s.Y) / 2
+}
+
+// ScaleFromStats returns the conversion of a run's mouse counts into unit, using its
+// "Sens Scale", "Horiz Sens", "Vert Sens" and "DPI". Pitch falls back to the yaw
+// conversion when the vertical sensitivity is missing. Centimetres only need DPI.
+func ScaleFromStats(stats map[string]any, unit string) (Scale, bool) {
+	dpi := util.ToFloat(stats["DPI"])
+	switch unit {
+	case "", UnitCounts:
+		return Counts, true
+	case UnitCentimetres:
+		if !isFinitePositive(dpi) {
+			return Scale{}, false
+		}
+		return Scale{Unit: UnitCentimetres, X: 2.54 / dpi, Y: 2.54 / dpi}, true
+	case UnitDegrees:
+		scale, _ := stats["Sens Scale"].(string)
+		x, ok := degPerCount(scale, util.ToFloat(stats["Horiz Sens"]), dpi)
+		if !ok {
+			return Scale{}, false
+		}
+		y, ok := degPerCount(scale, util.ToFloat(stats["Vert Sens"]), dpi)
+		if !ok {
+			y = x
+		}
+		return Scale{Unit: UnitDegrees, X: x, Y: y}, true
+	}
+	return Scale{}, false
+}
+
+// degPerCount returns the view rotation of one mouse count. Game scales with a known
+// yaw don't need DPI; physical scales (cm/360, in/360) do.
+func degPerCount(scale string, s, dpi float64) (float64, bool) {
+	if yaw, ok := yawByScale[scale]; ok {
+		if !isFinitePositive(s) {
+			return 0, false
+		}
+		return s * yaw, true
+	}
+	cm, ok := Cm360(scale, s, dpi)
+	if !ok || !isFinitePositive(dpi) {
+		return 0, false
+	}
+	return 360 / (cm / 2.54 * dpi), true
+}
+
+// Convert applies a scale to a trace, keeping positions relative to the first sample.
+func Convert(trace []models.MousePoint, sc Scale) []models.TracePoint {
+	out := make([]models.TracePoint, len(trace))
+	if len(trace) == 0 {
+		return out
+	}
+	x0, y0 := float64(trace[0].X), float64(trace[0].Y)
+	for i, p := range trace {
+		out[i] = models.TracePoint{TS: p.TS, X: (float64(p.X) - x0) * sc.X, Y: (float64(p.Y) - y0) * sc.Y}
+	}
+	return out
+}
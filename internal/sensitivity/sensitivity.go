@@ -0,0 +1,181 @@
+package sensitivity
+
+import (
+	"math"
+	"sort"
+
+	"refleks/internal/history"
+	"refleks/internal/models"
+	"refleks/internal/scenariotype"
+	"refleks/internal/util"
+)
+
+const (
+	// binRatio is the geometric width of a cm/360 bin, so bins are ~10% wide at any sensitivity.
+	binRatio = 1.1
+	// minBinRuns is the fewest runs for a bin to be considered for the best range.
+	minBinRuns = 5
+	// minRuns is the fewest runs with a known cm/360 needed for an analysis.
+	minRuns = 20
+)
+
+type obs struct {
+	cm    float64
+	resid float64 // detrended score relative to the scenario's typical score
+}
+
+// Analyze relates cm/360 to performance for every scenario and every scenario type.
+// Scenario results come first, sorted by name, followed by the scenario types.
+func Analyze(runs []history.Run) []models.SensitivityAnalysis {
+	byScenario := make(map[string][]history.Run)
+	for _, r := range runs {
+		byScenario[r.Scenario] = append(byScenario[r.Scenario], r)
+	}
+	names := make([]string, 0, len(byScenario))
+	for name := range byScenario {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var out []models.SensitivityAnalysis
+	byType := make(map[string][]obs)
+	for _, name := range names {
+		o := detrend(byScenario[name])
+		out = append(out, analyse(name, "scenario", o))
+		kind := scenariotype.Classify(name)
+		byType[kind] = append(byType[kind], o...)
+	}
+	types := make([]string, 0, len(byType))
+	for kind := range byType {
+		types = append(types, kind)
+	}
+	sort.Strings(types)
+	for _, kind := range types {
+		out = append(out, analyse(kind, "type", byType[kind]))
+	}
+	return out
+}
+
+// AnalyzeScenario relates cm/360 to performance for one scenario from its runs,
+// oldest first. It matches that scenario's entry in Analyze.
+func AnalyzeScenario(name string, runs []history.Run) models.SensitivityAnalysis {
+	return analyse(name, "scenario", detrend(runs))
+}
+
+// detrend removes a scenario's linear improvement over time from its scores, so a
+// sensitivity used later is not credited for the player having improved meanwhile.
+func detrend(runs []history.Run) []obs {
+	var xs, ys, cms []float64
+	for _, r := range runs {
+		cm := util.ToFloat(r.Stats["cm/360"])
+		if cm <= 0 || math.IsNaN(cm) || math.IsInf(cm, 0) {
+			continue
+		}
+		// Days relative to the first run keep the regression well conditioned.
+		xs = append(xs, r.End.Sub(runs[0].End).Hours()/24)
+		ys = append(ys, r.Score)
+		cms = append(cms, cm)
+	}
+	if len(ys) < 3 {
+		return nil
+	}
+	typical := util.Median(ys)
+	if typical <= 0 {
+		return nil
+	}
+	a, b := util.LinReg(xs, ys)
+	out := make([]obs, len(ys))
+	for i := range ys {
+		out[i] = obs{cm: cms[i], resid: (ys[i] - (a + b*xs[i])) / typical}
+	}
+	return out
+}
+
+type bin struct {
+	k    int
+	vals []float64
+}
+
+func analyse(group, kind string, o []obs) models.SensitivityAnalysis {
+	res := models.SensitivityAnalysis{Group: group, Kind: kind, Runs: len(o), Confidence: "low"}
+	if len(o) < minRuns {
+		res.Reason = "Need at least 20 runs with a known cm/360"
+		return res
+	}
+	byK := make(map[int]*bin)
+	for _, v := range o {
+		k := int(math.Floor(math.Log(v.cm) / math.Log(binRatio)))
+		b, ok := byK[k]
+		if !ok {
+			b = &bin{k: k}
+			byK[k] = b
+		}
+		b.vals = append(b.vals, v.resid)
+	}
+	bins := make([]*bin, 0, len(byK))
+	for _, b := range byK {
+		bins = append(bins, b)
+	}
+	sort.Slice(bins, func(i, j int) bool { return bins[i].k < bins[j].k })
+	best := -1
+	eligible := 0
+	for i, b := range bins {
+		mean, se := util.MeanSE(b.vals)
+		res.Bins = append(res.Bins, models.SensBin{
+			Lo:     math.Pow(binRatio, float64(b.k)),
+			Hi:     math.Pow(binRatio, float64(b.k+1)),
+			Runs:   len(b.vals),
+			Mean:   mean,
+			StdErr: se,
+		})
+		if len(b.vals) < minBinRuns {
+			continue
+		}
+		eligible++
+		if best < 0 || mean > res.Bins[best].Mean {
+			best = i
+		}
+	}
+	if eligible < 2 {
+		res.Reason = "Need enough runs at more than one sensitivity"
+		return res
+	}
+
+	// Grow the range over neighbouring bins that are within one standard error of the best.
+	floor := res.Bins[best].Mean - res.Bins[best].StdErr
+	lo, hi := best, best
+	for lo > 0 && bins[lo-1].k == bins[lo].k-1 && len(bins[lo-1].vals) >= minBinRuns && res.Bins[lo-1].Mean >= floor {
+		lo--
+	}
+	for hi < len(bins)-1 && bins[hi+1].k == bins[hi].k+1 && len(bins[hi+1].vals) >= minBinRuns && res.Bins[hi+1].Mean >= floor {
+		hi++
+	}
+	var in, out []float64
+	for i, b := range bins {
+		if i >= lo && i <= hi {
+			in = append(in, b.vals...)
+		} else {
+			out = append(out, b.vals...)
+		}
+	}
+	if len(out) < minBinRuns {
+		res.Reason = "No sensitivity range stands out"
+		return res
+	}
+	meanIn, seIn := util.MeanSE(in)
+	meanOut, seOut := util.MeanSE(out)
+	res.Sufficient = true
+	res.BestLo = res.Bins[lo].Lo
+	res.BestHi = res.Bins[hi].Hi
+	res.Gain = meanIn - meanOut
+	if se := math.Hypot(seIn, seOut); se > 0 {
+		res.TStat = res.Gain / se
+	}
+	switch {
+	case res.TStat >= 2.5:
+		res.Confidence = "high"
+	case res.TStat >= 1.5:
+		res.Confidence = "med"
+	}
+	return res
+}
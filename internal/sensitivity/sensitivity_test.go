@@ -0,0 +1,50 @@
+package sensitivity
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"refleks/internal/history"
+)
+
+func TestAnalyze(t *testing.T) {
+	start := time.Date(2025, 9, 1, 18, 0, 0, 0, time.UTC)
+	var runs []history.Run
+	// Alternate between three sensitivities while improving 2 points per day;
+	// runs at 40 cm/360 score 30 points above the trend.
+	cms := []float64{30, 40, 50}
+	for i := 0; i < 60; i++ {
+		cm := cms[i%3]
+		score := 1000 + 2*float64(i) + float64(i%2)*4
+		if cm == 40 {
+			score += 30
+		}
+		runs = append(runs, history.Run{
+			ID:       fmt.Sprint(i),
+			Scenario: "VT Ground Intermediate S5",
+			End:      start.Add(time.Duration(i) * 24 * time.Hour),
+			Score:    score,
+			Stats:    map[string]any{"cm/360": cm},
+		})
+	}
+	got := Analyze(runs)
+	if len(got) != 2 || got[0].Kind != "scenario" || got[1].Kind != "type" || got[1].Group != "Tracking" {
+		t.Fatalf("unexpected groups: %+v", got)
+	}
+	a := got[0]
+	if !a.Sufficient || a.BestLo > 40 || a.BestHi < 40 || a.BestHi > 50 || a.BestLo < 30 {
+		t.Fatalf("expected a best range around 40 cm/360, got %+v", a)
+	}
+	if a.Gain < 0.02 || a.Confidence != "high" {
+		t.Fatalf("expected a clear gain, got gain %v confidence %s", a.Gain, a.Confidence)
+	}
+	if one := AnalyzeScenario(a.Group, runs); !reflect.DeepEqual(one, a) {
+		t.Errorf("AnalyzeScenario = %+v, want the scenario entry of Analyze %+v", one, a)
+	}
+
+	if few := Analyze(runs[:10]); few[0].Sufficient || few[0].Reason == "" {
+		t.Fatalf("too few runs should be reported explicitly: %+v", few[0])
+	}
+}
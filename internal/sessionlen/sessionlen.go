@@ -0,0 +1,262 @@
+package sessionlen
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"refleks/internal/history"
+	"refleks/internal/models"
+	"refleks/internal/util"
+)
+
+const (
+	// MinSessions is the fewest sessions of a scenario needed for a recommendation.
+	MinSessions = 5
+	// bootstrapRounds controls how many session resamples estimate the uncertainty.
+	bootstrapRounds = 200
+)
+
+// Analyze groups runs into sessions and recommends a session length for every
+// scenario, sorted by scenario name. Only scores are considered.
+func Analyze(runs []history.Run, gap time.Duration) []models.SessionLengthRecommendation {
+	perScenario := make(map[string][][]float64)
+	for _, sess := range history.GroupSessions(runs, gap) {
+		bySc := make(map[string][]history.Run)
+		for _, r := range sess {
+			bySc[r.Scenario] = append(bySc[r.Scenario], r)
+		}
+		for name, rs := range bySc {
+			sort.SliceStable(rs, func(i, j int) bool { return rs[i].End.Before(rs[j].End) })
+			scores := make([]float64, len(rs))
+			for i, r := range rs {
+				scores[i] = r.Score
+			}
+			perScenario[name] = append(perScenario[name], scores)
+		}
+	}
+	out := make([]models.SessionLengthRecommendation, 0, len(perScenario))
+	for name, bySession := range perScenario {
+		out = append(out, Recommend(name, bySession))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Scenario < out[j].Scenario })
+	return out
+}
+
+// Recommend analyses the scores of one scenario, grouped by session (oldest run
+// first within each session). The session order does not matter.
+func Recommend(scenario string, bySession [][]float64) models.SessionLengthRecommendation {
+	out := models.SessionLengthRecommendation{Scenario: scenario, Sessions: len(bySession)}
+	if len(bySession) < MinSessions {
+		out.Reason = "Need at least 5 sessions with this scenario"
+		return out
+	}
+	r := recommend(bySession)
+	out.Sufficient = true
+	out.WarmupRuns = r.warmup
+	out.OptimalAvgRuns = r.avg
+	out.OptimalConsistentRuns = r.consistent
+	out.OptimalHighscoreRuns = r.highscore
+	out.RecommendedRuns = r.highscore
+	out.ExpectedBest = expectedBestVsLength(bySession)
+	out.ExpectedAvg = expectedAvgVsLength(bySession)
+
+	// Resample sessions with replacement; a fixed seed keeps results stable between calls.
+	rng := rand.New(rand.NewSource(1))
+	samples := make([]float64, 0, bootstrapRounds)
+	resampled := make([][]float64, len(bySession))
+	for i := 0; i < bootstrapRounds; i++ {
+		for j := range resampled {
+			resampled[j] = bySession[rng.Intn(len(bySession))]
+		}
+		samples = append(samples, float64(recommend(resampled).highscore))
+	}
+	sort.Float64s(samples)
+	out.RecommendedLo = int(percentile(samples, 10))
+	out.RecommendedHi = int(percentile(samples, 90))
+	return out
+}
+
+type lengths struct {
+	warmup, avg, consistent, highscore int
+}
+
+func recommend(bySession [][]float64) lengths {
+	mean, std := expectedByIndex(bySession)
+	bestVsL := expectedBestVsLength(bySession)
+	stats := expectedAvgVsLength(bySession)
+	n := len(mean)
+	out := lengths{warmup: 1, avg: 1, consistent: n, highscore: 1}
+
+	// Warm-up: first index where the mean stops moving faster than usual and the spread
+	// is no wider than usual.
+	absSlopes := make([]float64, 0, n)
+	for i := 1; i < n; i++ {
+		absSlopes = append(absSlopes, math.Abs(mean[i]-mean[i-1]))
+	}
+	slopeMed, stdMed := util.Median(absSlopes), util.Median(std)
+	for i := 1; i < n; i++ {
+		if absSlopes[i-1] <= slopeMed && std[i] <= stdMed {
+			out.warmup = i + 1
+			break
+		}
+	}
+
+	// Average: smallest L whose prefix average is within 1% of the best one.
+	bestVal := math.Inf(-1)
+	for _, s := range stats {
+		bestVal = math.Max(bestVal, s.Mean)
+	}
+	eps := 0.01 * nonZero(math.Abs(bestVal))
+	for i, s := range stats {
+		if bestVal-s.Mean <= eps {
+			out.avg = i + 1
+			break
+		}
+	}
+
+	// Consistency: smallest L where the recent interdecile range is below its median.
+	variability := make([]float64, len(stats))
+	for i, s := range stats {
+		variability[i] = math.Max(0, s.P90-s.P10)
+	}
+	varMed := util.Median(variability)
+	for l := 2; l <= len(variability); l++ {
+		k := min(3, l)
+		var sum float64
+		for _, v := range variability[l-k : l] {
+			sum += v
+		}
+		if sum/float64(k) <= varMed {
+			out.consistent = l
+			break
+		}
+	}
+
+	// Highscore: smallest L within 1% of the best expected best-of-L where one more
+	// run gains less than 2%.
+	hsBest, hsBestL := math.Inf(-1), 1
+	for i, v := range bestVsL {
+		if v > hsBest {
+			hsBest, hsBestL = v, i+1
+		}
+	}
+	out.highscore = hsBestL
+	hsEps := 0.01 * nonZero(hsBest)
+	for i, v := range bestVsL {
+		next := v
+		if i+1 < len(bestVsL) {
+			next = bestVsL[i+1]
+		}
+		var marginal float64
+		if hsBest != 0 {
+			marginal = (next - v) / hsBest
+		}
+		if hsBest-v <= hsEps && marginal < 0.02 {
+			out.highscore = i + 1
+			break
+		}
+	}
+	return out
+}
+
+func maxLen(bySession [][]float64) int {
+	n := 0
+	for _, s := range bySession {
+		n = max(n, len(s))
+	}
+	return n
+}
+
+// expectedByIndex returns the mean and standard deviation of the j-th run of a session.
+func expectedByIndex(bySession [][]float64) (mean, std []float64) {
+	n := maxLen(bySession)
+	mean, std = make([]float64, n), make([]float64, n)
+	for j := 0; j < n; j++ {
+		var vals []float64
+		for _, s := range bySession {
+			if j < len(s) {
+				vals = append(vals, s[j])
+			}
+		}
+		mean[j], std[j] = meanStd(vals)
+	}
+	return mean, std
+}
+
+// expectedBestVsLength returns, for each L, the mean best score over a session's first L runs.
+func expectedBestVsLength(bySession [][]float64) []float64 {
+	n := maxLen(bySession)
+	curve := make([]float64, n)
+	for l := 1; l <= n; l++ {
+		var sum float64
+		for _, s := range bySession {
+			best := math.Inf(-1)
+			for _, v := range s[:min(l, len(s))] {
+				best = math.Max(best, v)
+			}
+			sum += best
+		}
+		curve[l-1] = sum / float64(len(bySession))
+	}
+	return curve
+}
+
+// expectedAvgVsLength returns, for each L, the distribution of per-session averages
+// over the first L runs among sessions with at least L runs.
+func expectedAvgVsLength(bySession [][]float64) []models.LengthStats {
+	n := maxLen(bySession)
+	out := make([]models.LengthStats, n)
+	for l := 1; l <= n; l++ {
+		var avgs []float64
+		for _, s := range bySession {
+			if len(s) >= l {
+				m, _ := meanStd(s[:l])
+				avgs = append(avgs, m)
+			}
+		}
+		st := models.LengthStats{Runs: l, Count: len(avgs)}
+		if len(avgs) > 0 {
+			sort.Float64s(avgs)
+			st.Mean, st.Std = meanStd(avgs)
+			st.Min, st.Max = avgs[0], avgs[len(avgs)-1]
+			st.P10, st.P90 = percentile(avgs, 10), percentile(avgs, 90)
+		}
+		out[l-1] = st
+	}
+	return out
+}
+
+func meanStd(vals []float64) (float64, float64) {
+	if len(vals) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range vals {
+		sum += v
+	}
+	m := sum / float64(len(vals))
+	var ss float64
+	for _, v := range vals {
+		ss += (v - m) * (v - m)
+	}
+	return m, math.Sqrt(ss / float64(len(vals)))
+}
+
+// percentile returns the nearest-rank p-th percentile (0..100) of sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(math.Min(100, math.Max(0, p))/100*float64(n))) - 1
+	return sorted[min(n-1, max(0, rank))]
+}
+
+func nonZero(v float64) float64 {
+	if v == 0 {
+		return 1
+	}
+	return v
+}
@@ -0,0 +1,28 @@
+package sessionlen
+
+import "testing"
+
+func TestRecommend(t *testing.T) {
+	if got := Recommend("few", [][]float64{{1, 2}, {3}}); got.Sufficient || got.Reason == "" || got.RecommendedRuns != 0 {
+		t.Fatalf("too few sessions should be reported explicitly: %+v", got)
+	}
+
+	// Scores climb for three runs and then level off in every session.
+	sessions := [][]float64{
+		{100, 110, 120, 121, 119},
+		{101, 111, 121, 120, 118},
+		{99, 109, 119, 120, 117},
+		{100, 112, 122, 121},
+		{98, 108, 118, 119, 116, 115},
+	}
+	got := Recommend("climb", sessions)
+	if !got.Sufficient || got.RecommendedRuns != 3 {
+		t.Fatalf("expected 3 recommended runs, got %+v", got)
+	}
+	if got.RecommendedLo > got.RecommendedRuns || got.RecommendedHi < got.RecommendedRuns {
+		t.Fatalf("interval %d..%d does not contain %d", got.RecommendedLo, got.RecommendedHi, got.RecommendedRuns)
+	}
+	if len(got.ExpectedBest) != 6 || got.ExpectedAvg[0].Count != 5 || got.ExpectedAvg[5].Count != 1 {
+		t.Fatalf("unexpected curves: %+v", got)
+	}
+}
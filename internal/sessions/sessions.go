@@ -0,0 +1,241 @@
+package sessions
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"refleks/internal/models"
+)
+
+// Event names emitted by the watcher as sessions evolve.
+const (
+	EventStarted = "SessionStarted"
+	EventUpdated = "SessionUpdated"
+	EventEnded   = "SessionEnded"
+	// EventReset carries no session: every session was regrouped (filter rules, gap or
+	// history changed) and listeners should reload them with GetSessions.
+	EventReset = "SessionsReset"
+)
+
+// Run is the per-run input for session grouping.
+type Run struct {
+	ID       string // stats file name
+	Scenario string
+	Start    time.Time
+	End      time.Time
+	Score    float64
+	Accuracy float64
+}
+
+// Event pairs an event name with the session snapshot it refers to.
+type Event struct {
+	Name    string
+	Session models.Session
+}
+
+type session struct {
+	runs  []Run // oldest first
+	ended bool
+}
+
+func (s *session) start() time.Time { return s.runs[0].Start }
+
+func (s *session) end() time.Time {
+	end := s.runs[0].End
+	for _, r := range s.runs[1:] {
+		if r.End.After(end) {
+			end = r.End
+		}
+	}
+	return end
+}
+
+// Group splits runs into sessions. A new session starts whenever the idle time between
+// the end of one run and the start of the next exceeds gap. Sessions are returned
+// newest first and are all reported as inactive.
+func Group(runs []Run, gap time.Duration) []models.Session {
+	t := NewTracker(gap)
+	t.Reset(runs)
+	t.mu.Lock()
+	for _, s := range t.sessions {
+		s.ended = true
+	}
+	t.mu.Unlock()
+	return t.Sessions(0)
+}
+
+// Tracker maintains sessions incrementally as runs arrive. It is safe for concurrent use.
+type Tracker struct {
+	mu       sync.Mutex
+	gap      time.Duration
+	sessions []*session // oldest first
+}
+
+// NewTracker returns an empty tracker using the given session gap.
+func NewTracker(gap time.Duration) *Tracker {
+	return &Tracker{gap: gap}
+}
+
+// Reset replaces all state with sessions grouped from runs, without producing events.
+// Every session but the most recent is considered ended.
+func (t *Tracker) Reset(runs []Run) {
+	sorted := append([]Run(nil), runs...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sessions = nil
+	for _, r := range sorted {
+		if n := len(t.sessions); n > 0 && !t.startsNewLocked(t.sessions[n-1], r) {
+			t.sessions[n-1].runs = append(t.sessions[n-1].runs, r)
+			continue
+		}
+		if n := len(t.sessions); n > 0 {
+			t.sessions[n-1].ended = true
+		}
+		t.sessions = append(t.sessions, &session{runs: []Run{r}})
+	}
+}
+
+// Add records a run and returns the resulting events. Runs normally arrive in
+// chronological order; an older run is merged into history and regrouped.
+func (t *Tracker) Add(r Run) []Event {
+	t.mu.Lock()
+	n := len(t.sessions)
+	if n > 0 && r.Start.Before(t.sessions[n-1].start()) {
+		t.mu.Unlock()
+		return t.addOutOfOrder(r)
+	}
+	defer t.mu.Unlock()
+	var events []Event
+	if n == 0 || t.startsNewLocked(t.sessions[n-1], r) {
+		if n > 0 && !t.sessions[n-1].ended {
+			t.sessions[n-1].ended = true
+			events = append(events, Event{Name: EventEnded, Session: t.sessions[n-1].snapshot()})
+		}
+		s := &session{runs: []Run{r}}
+		t.sessions = append(t.sessions, s)
+		return append(events, Event{Name: EventStarted, Session: s.snapshot()})
+	}
+	last := t.sessions[n-1]
+	last.runs = append(last.runs, r)
+	sort.SliceStable(last.runs, func(i, j int) bool { return last.runs[i].Start.Before(last.runs[j].Start) })
+	// A late run can reopen a session that was expired while idle.
+	last.ended = false
+	return append(events, Event{Name: EventUpdated, Session: last.snapshot()})
+}
+
+// addOutOfOrder regroups all runs after a run older than the latest session arrives.
+func (t *Tracker) addOutOfOrder(r Run) []Event {
+	t.mu.Lock()
+	var all []Run
+	for _, s := range t.sessions {
+		all = append(all, s.runs...)
+	}
+	lastEnded := len(t.sessions) > 0 && t.sessions[len(t.sessions)-1].ended
+	t.mu.Unlock()
+
+	t.Reset(append(all, r))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if n := len(t.sessions); n > 0 {
+		t.sessions[n-1].ended = lastEnded
+	}
+	for _, s := range t.sessions {
+		for _, sr := range s.runs {
+			if sr.ID == r.ID {
+				return []Event{{Name: EventUpdated, Session: s.snapshot()}}
+			}
+		}
+	}
+	return nil
+}
+
+// Expire ends the most recent session once now is past its last run by more than
+// the gap. Call it periodically so SessionEnded fires without waiting for a new run.
+func (t *Tracker) Expire(now time.Time) []Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := len(t.sessions)
+	if n == 0 || t.sessions[n-1].ended {
+		return nil
+	}
+	last := t.sessions[n-1]
+	if now.Sub(last.end()) <= t.gap {
+		return nil
+	}
+	last.ended = true
+	return []Event{{Name: EventEnded, Session: last.snapshot()}}
+}
+
+// Sessions returns up to limit sessions, newest first. limit <= 0 returns all.
+func (t *Tracker) Sessions(limit int) []models.Session {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	total := len(t.sessions)
+	if limit <= 0 || limit > total {
+		limit = total
+	}
+	out := make([]models.Session, 0, limit)
+	for i := 0; i < limit; i++ {
+		out = append(out, t.sessions[total-1-i].snapshot())
+	}
+	return out
+}
+
+// Starts returns the start time of every session, oldest first. Sessions cover
+// consecutive time ranges, so a run belongs to the last session starting at or before it.
+func (t *Tracker) Starts() []time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]time.Time, len(t.sessions))
+	for i, s := range t.sessions {
+		out[i] = s.start()
+	}
+	return out
+}
+
+// startsNewLocked reports whether r is too far after s to belong to it.
+func (t *Tracker) startsNewLocked(s *session, r Run) bool {
+	return r.Start.Sub(s.end()) > t.gap
+}
+
+// snapshot computes the IPC view of a session, including per-scenario aggregates.
+func (s *session) snapshot() models.Session {
+	start, end := s.start(), s.end()
+	out := models.Session{
+		ID:     fmt.Sprintf("sess-%d", start.UnixMilli()),
+		Start:  start.Format(time.RFC3339),
+		End:    end.Format(time.RFC3339),
+		Active: !s.ended,
+		Runs:   len(s.runs),
+		RunIDs: make([]string, 0, len(s.runs)),
+	}
+	idx := make(map[string]int)
+	var accSum float64
+	for _, r := range s.runs {
+		out.RunIDs = append(out.RunIDs, r.ID)
+		if d := r.End.Sub(r.Start); d > 0 {
+			out.PlaySeconds += d.Seconds()
+		}
+		accSum += r.Accuracy
+		i, ok := idx[r.Scenario]
+		if !ok {
+			i = len(out.Scenarios)
+			idx[r.Scenario] = i
+			out.Scenarios = append(out.Scenarios, models.SessionScenario{Name: r.Scenario, BestScore: r.Score})
+		}
+		sc := &out.Scenarios[i]
+		sc.Runs++
+		if r.Score > sc.BestScore {
+			sc.BestScore = r.Score
+		}
+		// Running means keep this single-pass
+		sc.AvgScore += (r.Score - sc.AvgScore) / float64(sc.Runs)
+		sc.AvgAccuracy += (r.Accuracy - sc.AvgAccuracy) / float64(sc.Runs)
+	}
+	out.AvgAccuracy = accSum / float64(len(s.runs))
+	return out
+}
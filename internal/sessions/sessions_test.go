@@ -0,0 +1,68 @@
+package sessions
+
+import (
+	"testing"
+	"time"
+)
+
+func run(id string, start time.Time, score float64) Run {
+	return Run{ID: id, Scenario: "VT Ground Intermediate S5", Start: start, End: start.Add(time.Minute), Score: score, Accuracy: 0.5}
+}
+
+func TestTrackerLifecycle(t *testing.T) {
+	base := time.Date(2025, 10, 2, 18, 0, 0, 0, time.Local)
+	tr := NewTracker(15 * time.Minute)
+
+	if ev := tr.Add(run("a", base, 100)); len(ev) != 1 || ev[0].Name != EventStarted {
+		t.Fatalf("first run: expected SessionStarted, got %+v", ev)
+	}
+	if ev := tr.Add(run("b", base.Add(10*time.Minute), 120)); len(ev) != 1 || ev[0].Name != EventUpdated {
+		t.Fatalf("second run: expected SessionUpdated, got %+v", ev)
+	}
+	if ev := tr.Expire(base.Add(20 * time.Minute)); len(ev) != 0 {
+		t.Fatalf("expire within gap: expected no events, got %+v", ev)
+	}
+	ev := tr.Add(run("c", base.Add(2*time.Hour), 90))
+	if len(ev) != 2 || ev[0].Name != EventEnded || ev[1].Name != EventStarted {
+		t.Fatalf("run after gap: expected SessionEnded+SessionStarted, got %+v", ev)
+	}
+	if ev := tr.Expire(base.Add(3 * time.Hour)); len(ev) != 1 || ev[0].Name != EventEnded {
+		t.Fatalf("idle expiry: expected SessionEnded, got %+v", ev)
+	}
+
+	got := tr.Sessions(0)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(got))
+	}
+	first := got[1]
+	if first.Runs != 2 || len(first.Scenarios) != 1 || first.Scenarios[0].BestScore != 120 || first.Scenarios[0].AvgScore != 110 {
+		t.Fatalf("unexpected aggregates: %+v", first)
+	}
+	if first.PlaySeconds != 120 || first.Active {
+		t.Fatalf("unexpected play time/active: %+v", first)
+	}
+}
+
+func TestGroupMergesOutOfOrderRuns(t *testing.T) {
+	base := time.Date(2025, 10, 2, 18, 0, 0, 0, time.Local)
+	runs := []Run{
+		run("c", base.Add(20*time.Minute), 1),
+		run("a", base, 1),
+		run("b", base.Add(10*time.Minute), 1),
+		run("d", base.Add(time.Hour), 1),
+	}
+	got := Group(runs, 15*time.Minute)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(got))
+	}
+	if ids := got[1].RunIDs; len(ids) != 3 || ids[0] != "a" || ids[2] != "c" {
+		t.Fatalf("expected oldest session a,b,c, got %v", ids)
+	}
+
+	tr := NewTracker(15 * time.Minute)
+	tr.Add(runs[0])
+	tr.Add(runs[3])
+	if ev := tr.Add(runs[2]); len(ev) != 1 || ev[0].Session.Runs != 2 {
+		t.Fatalf("out-of-order add: expected update of the earlier session, got %+v", ev)
+	}
+}
@@ -97,6 +97,15 @@ func DefaultTracesDir() (string, error) {
 	return filepath.Join(base, constants.TracesSubdirName), nil
 }
 
+// DefaultArchiveDir returns the directory holding compressed stats archives ($HOME/.refleks/archive).
+func DefaultArchiveDir() (string, error) {
+	base, err := ConfigBaseDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, constants.ArchiveSubdirName), nil
+}
+
 // ExpandPathPlaceholders normalizes a path string for the current OS. No placeholders are supported.
 func ExpandPathPlaceholders(p string) string {
 	if p == "" {
@@ -0,0 +1,275 @@
+package setup
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"refleks/internal/history"
+	"refleks/internal/models"
+	"refleks/internal/util"
+)
+
+// Setup categories reported on a change point.
+const (
+	CategorySensitivity = "sensitivity"
+	CategoryFOV         = "fov"
+	CategoryCrosshair   = "crosshair"
+	CategoryResolution  = "resolution"
+)
+
+// keys are the settings that define a setup, in report order, with their category.
+// Sensitivity is tracked as cm/360 so switching sens scale or game profile without
+// changing the physical sensitivity is not a change.
+var keys = []struct {
+	key      string
+	category string
+}{
+	{"cm/360", CategorySensitivity},
+	{"DPI", CategorySensitivity},
+	{"FOV", CategoryFOV},
+	{"FOVScale", CategoryFOV},
+	{"Crosshair", CategoryCrosshair},
+	{"Crosshair Scale", CategoryCrosshair},
+	{"Crosshair Color", CategoryCrosshair},
+	{"Resolution", CategoryResolution},
+}
+
+const (
+	// minEpochRuns is how many consecutive runs must share a new setup before it
+	// counts as a change; shorter blips are left out of every epoch.
+	minEpochRuns = 3
+	// window is the most runs per scenario compared on each side of a change.
+	window = 20
+	// minSide is the fewest runs per scenario needed on each side of a change.
+	minSide = 5
+)
+
+type epoch struct {
+	settings map[string]string
+	runs     []history.Run
+}
+
+// Analyze splits runs (oldest first) into setup epochs and measures, per scenario,
+// how performance after each change compares with the learning trend before it.
+func Analyze(runs []history.Run) models.SetupAnalysis {
+	epochs := split(runs)
+	out := models.SetupAnalysis{Epochs: []models.SetupEpoch{}, Changes: []models.SetupChangePoint{}}
+	for i, e := range epochs {
+		out.Epochs = append(out.Epochs, models.SetupEpoch{
+			Index:    i,
+			Start:    e.runs[0].End.Format(time.RFC3339),
+			End:      e.runs[len(e.runs)-1].End.Format(time.RFC3339),
+			Runs:     len(e.runs),
+			Settings: e.settings,
+		})
+		if i > 0 {
+			out.Changes = append(out.Changes, changePoint(i, epochs[i-1], e))
+		}
+	}
+	return out
+}
+
+// split groups runs into epochs. A run whose setup differs from the current epoch
+// starts a new one only if the next minEpochRuns runs agree on the new values.
+func split(runs []history.Run) []*epoch {
+	var out []*epoch
+	for i, r := range runs {
+		s := settings(r)
+		if len(s) == 0 {
+			continue
+		}
+		if len(out) == 0 {
+			out = append(out, &epoch{settings: s, runs: []history.Run{r}})
+			continue
+		}
+		cur := out[len(out)-1]
+		diff := differing(cur.settings, s)
+		if len(diff) == 0 {
+			for k, v := range s {
+				if _, ok := cur.settings[k]; !ok {
+					cur.settings[k] = v
+				}
+			}
+			cur.runs = append(cur.runs, r)
+			continue
+		}
+		if !stable(runs[i:], s, diff) {
+			continue
+		}
+		next := make(map[string]string, len(cur.settings))
+		for k, v := range cur.settings {
+			next[k] = v
+		}
+		for k, v := range s {
+			next[k] = v
+		}
+		out = append(out, &epoch{settings: next, runs: []history.Run{r}})
+	}
+	return out
+}
+
+// settings returns the setup values a run reports.
+func settings(r history.Run) map[string]string {
+	out := make(map[string]string)
+	for _, k := range keys {
+		v, ok := r.Stats[k.key]
+		if !ok {
+			continue
+		}
+		if k.key == "cm/360" {
+			cm := util.ToFloat(v)
+			// 0 means the sens scale is not supported.
+			if cm <= 0 || math.IsNaN(cm) || math.IsInf(cm, 0) {
+				continue
+			}
+			out[k.key] = strconv.FormatFloat(math.Round(cm*10)/10, 'f', 1, 64)
+			continue
+		}
+		out[k.key] = fmt.Sprint(v)
+	}
+	return out
+}
+
+// differing returns the keys both setups report with different values.
+func differing(a, b map[string]string) []string {
+	var out []string
+	for _, k := range keys {
+		va, okA := a[k.key]
+		vb, okB := b[k.key]
+		if okA && okB && va != vb {
+			out = append(out, k.key)
+		}
+	}
+	return out
+}
+
+// stable reports whether the first minEpochRuns runs share s's values for the changed keys.
+func stable(runs []history.Run, s map[string]string, changed []string) bool {
+	if len(runs) < minEpochRuns {
+		return false
+	}
+	for _, r := range runs[:minEpochRuns] {
+		o := settings(r)
+		for _, k := range changed {
+			if o[k] != s[k] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func changePoint(idx int, before, after *epoch) models.SetupChangePoint {
+	cp := models.SetupChangePoint{
+		At:         after.runs[0].End.Format(time.RFC3339),
+		Epoch:      idx,
+		Categories: []string{},
+		Impacts:    []models.SetupImpact{},
+	}
+	seen := make(map[string]bool)
+	for _, k := range keys {
+		b, a := before.settings[k.key], after.settings[k.key]
+		if b == "" || a == "" || b == a {
+			continue
+		}
+		cp.Changes = append(cp.Changes, models.SetupChange{Key: k.key, Before: b, After: a})
+		if !seen[k.category] {
+			seen[k.category] = true
+			cp.Categories = append(cp.Categories, k.category)
+		}
+	}
+
+	prev, next := byScenario(before.runs), byScenario(after.runs)
+	for name, rs := range prev {
+		if imp, ok := impact(name, rs[max(0, len(rs)-window):], next[name][:min(len(next[name]), window)]); ok {
+			cp.Impacts = append(cp.Impacts, imp)
+		}
+	}
+	sort.Slice(cp.Impacts, func(i, j int) bool {
+		if ti, tj := math.Abs(cp.Impacts[i].TStat), math.Abs(cp.Impacts[j].TStat); ti != tj {
+			return ti > tj
+		}
+		return cp.Impacts[i].Scenario < cp.Impacts[j].Scenario
+	})
+	for _, imp := range cp.Impacts {
+		cp.MeanEffect += imp.Effect / float64(len(cp.Impacts))
+	}
+	return cp
+}
+
+func byScenario(runs []history.Run) map[string][]history.Run {
+	out := make(map[string][]history.Run)
+	for _, r := range runs {
+		out[r.Scenario] = append(out[r.Scenario], r)
+	}
+	return out
+}
+
+// impact fits the learning trend over the runs before a change, by attempt, and
+// measures how far the runs after it land from the trend's continuation.
+func impact(name string, before, after []history.Run) (models.SetupImpact, bool) {
+	if len(before) < minSide || len(after) < minSide {
+		return models.SetupImpact{}, false
+	}
+	var xs, ys []float64
+	for i, r := range before {
+		xs = append(xs, float64(i))
+		ys = append(ys, r.Score)
+	}
+	typical := util.Median(ys)
+	if typical <= 0 {
+		return models.SetupImpact{}, false
+	}
+	a, b := util.LinReg(xs, ys)
+	imp := models.SetupImpact{Scenario: name, RunsBefore: len(before), RunsAfter: len(after), Confidence: "low"}
+	residBefore := make([]float64, len(before))
+	for i, y := range ys {
+		residBefore[i] = (y - (a + b*xs[i])) / typical
+		imp.MeanBefore += y / float64(len(ys))
+	}
+	residAfter := make([]float64, len(after))
+	for j, r := range after {
+		pred := a + b*float64(len(before)+j)
+		residAfter[j] = (r.Score - pred) / typical
+		imp.MeanAfter += r.Score / float64(len(after))
+		imp.Expected += pred / float64(len(after))
+	}
+	mb, seB, sdB := stats(residBefore)
+	ma, seA, sdA := stats(residAfter)
+	imp.Effect = ma - mb
+	nb, na := float64(len(before)), float64(len(after))
+	if pooled := math.Sqrt(((nb-1)*sdB*sdB + (na-1)*sdA*sdA) / (nb + na - 2)); pooled > 0 {
+		imp.EffectSize = imp.Effect / pooled
+	}
+	if se := math.Hypot(seB, seA); se > 0 {
+		imp.TStat = imp.Effect / se
+	}
+	switch t := math.Abs(imp.TStat); {
+	case t >= 2.5:
+		imp.Confidence = "high"
+	case t >= 1.5:
+		imp.Confidence = "med"
+	}
+	return imp, true
+}
+
+// stats returns the mean, its standard error and the sample standard deviation.
+func stats(vals []float64) (mean, se, sd float64) {
+	n := float64(len(vals))
+	for _, v := range vals {
+		mean += v
+	}
+	mean /= n
+	if n < 2 {
+		return mean, 0, 0
+	}
+	var ss float64
+	for _, v := range vals {
+		ss += (v - mean) * (v - mean)
+	}
+	sd = math.Sqrt(ss / (n - 1))
+	return mean, sd / math.Sqrt(n), sd
+}
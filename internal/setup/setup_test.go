@@ -0,0 +1,58 @@
+package setup
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"refleks/internal/history"
+)
+
+func TestAnalyze(t *testing.T) {
+	start := time.Date(2025, 9, 1, 18, 0, 0, 0, time.UTC)
+	var runs []history.Run
+	play := func(scenario string, score float64, fov string) {
+		runs = append(runs, history.Run{
+			ID:       fmt.Sprint(len(runs)),
+			Scenario: scenario,
+			End:      start.Add(time.Duration(len(runs)) * time.Hour),
+			Score:    score,
+			Stats:    map[string]any{"cm/360": 34.02, "FOV": fov, "Crosshair": "ch.png"},
+		})
+	}
+	// A improves 10 points per run with some noise; after the FOV change it keeps
+	// improving but sits 100 points above the trend. B just keeps improving.
+	noise := []float64{4, -3, 1, -2, 0}
+	for i := 0; i < 20; i++ {
+		play("A", 1000+10*float64(i)+noise[i%5], "90.0")
+		play("B", 500+5*float64(i)+noise[(i+2)%5], "90.0")
+	}
+	// A one-off FOV blip is not a change.
+	play("A", 1200, "100.0")
+	for i := 20; i < 40; i++ {
+		play("A", 1100+10*float64(i)+noise[i%5], "103.0")
+		play("B", 500+5*float64(i)+noise[(i+2)%5], "103.0")
+	}
+
+	got := Analyze(runs)
+	if len(got.Epochs) != 2 || got.Epochs[0].Runs != 40 || got.Epochs[1].Settings["FOV"] != "103.0" {
+		t.Fatalf("unexpected epochs %+v", got.Epochs)
+	}
+	if len(got.Changes) != 1 {
+		t.Fatalf("expected one change point, got %+v", got.Changes)
+	}
+	cp := got.Changes[0]
+	if len(cp.Changes) != 1 || cp.Changes[0].Key != "FOV" || cp.Categories[0] != CategoryFOV {
+		t.Errorf("unexpected setup diff %+v %v", cp.Changes, cp.Categories)
+	}
+	if len(cp.Impacts) != 2 || cp.Impacts[0].Scenario != "A" {
+		t.Fatalf("unexpected impacts %+v", cp.Impacts)
+	}
+	a, b := cp.Impacts[0], cp.Impacts[1]
+	if a.Effect < 0.05 || a.Confidence != "high" || a.EffectSize < 2 {
+		t.Errorf("expected a clear gain for A, got %+v", a)
+	}
+	if b.Effect > 0.01 || b.Effect < -0.01 || b.Confidence == "high" {
+		t.Errorf("B only followed its trend, got %+v", b)
+	}
+}
@@ -0,0 +1,145 @@
+package timeofday
+
+import (
+	"math"
+	"sort"
+
+	"refleks/internal/history"
+	"refleks/internal/models"
+	"refleks/internal/util"
+)
+
+const (
+	// minScenarioRuns is the fewest runs a scenario needs before its runs can be ranked.
+	minScenarioRuns = 5
+	// minBucketRuns is the fewest runs for an hour or weekday bucket to be trusted.
+	minBucketRuns = 10
+	// minWindowRuns is the fewest runs for a best/worst window to be reported.
+	minWindowRuns = 30
+	// windowHours is the width of the best/worst windows.
+	windowHours = 3
+	minRuns     = 50
+)
+
+type obs struct {
+	hour, weekday int
+	pct           float64 // percentile within the scenario's history
+	rel           float64 // score relative to the scenario median
+}
+
+// Analyze buckets every run by the local hour and weekday it was played (taken from
+// the stats file name) and compares normalized performance across buckets.
+func Analyze(runs []history.Run) models.TimeOfDayAnalysis {
+	byScenario := make(map[string][]history.Run)
+	for _, r := range runs {
+		byScenario[r.Scenario] = append(byScenario[r.Scenario], r)
+	}
+	var all []obs
+	out := models.TimeOfDayAnalysis{}
+	for _, rs := range byScenario {
+		if len(rs) < minScenarioRuns {
+			continue
+		}
+		out.Scenarios++
+		all = append(all, normalize(rs)...)
+	}
+	out.Runs = len(all)
+	if len(all) < minRuns {
+		out.Reason = "Need at least 50 runs on scenarios with 5 or more runs"
+		return out
+	}
+	out.Sufficient = true
+	var overall float64
+	for _, o := range all {
+		overall += o.rel
+	}
+	overall /= float64(len(all))
+
+	hours := make([][]obs, 24)
+	days := make([][]obs, 7)
+	for _, o := range all {
+		hours[o.hour] = append(hours[o.hour], o)
+		days[o.weekday] = append(days[o.weekday], o)
+	}
+	for h, b := range hours {
+		out.ByHour = append(out.ByHour, bucket(h, b, overall))
+	}
+	for d, b := range days {
+		out.ByWeekday = append(out.ByWeekday, bucket(d, b, overall))
+	}
+
+	for start := 0; start < 24; start++ {
+		var in, rest []float64
+		for _, o := range all {
+			if (o.hour-start+24)%24 < windowHours {
+				in = append(in, o.rel)
+			} else {
+				rest = append(rest, o.rel)
+			}
+		}
+		if len(in) < minWindowRuns || len(rest) < minWindowRuns {
+			continue
+		}
+		mIn, seIn := util.MeanSE(in)
+		mRest, seRest := util.MeanSE(rest)
+		w := models.TimeWindow{StartHour: start, EndHour: (start + windowHours) % 24, Runs: len(in), ScoreDelta: mIn - overall}
+		if se := math.Hypot(seIn, seRest); se > 0 {
+			w.TStat = (mIn - mRest) / se
+		}
+		if out.BestWindow == nil || w.ScoreDelta > out.BestWindow.ScoreDelta {
+			best := w
+			out.BestWindow = &best
+		}
+		if out.WorstWindow == nil || w.ScoreDelta < out.WorstWindow.ScoreDelta {
+			worst := w
+			out.WorstWindow = &worst
+		}
+	}
+	return out
+}
+
+// normalize ranks each run of one scenario against the scenario's full history.
+func normalize(rs []history.Run) []obs {
+	scores := make([]float64, len(rs))
+	for i, r := range rs {
+		scores[i] = r.Score
+	}
+	sort.Float64s(scores)
+	med := scores[len(scores)/2]
+	if len(scores)%2 == 0 {
+		med = (scores[len(scores)/2-1] + med) / 2
+	}
+	out := make([]obs, 0, len(rs))
+	for _, r := range rs {
+		if med <= 0 {
+			break
+		}
+		below := sort.SearchFloat64s(scores, r.Score)
+		equal := sort.SearchFloat64s(scores, math.Nextafter(r.Score, math.Inf(1))) - below
+		out = append(out, obs{
+			hour:    r.End.Hour(),
+			weekday: int(r.End.Weekday()),
+			pct:     (float64(below) + 0.5*float64(equal)) / float64(len(scores)),
+			rel:     r.Score/med - 1,
+		})
+	}
+	return out
+}
+
+func bucket(key int, b []obs, overall float64) models.TimeBucket {
+	tb := models.TimeBucket{Key: key, Runs: len(b), Sufficient: len(b) >= minBucketRuns}
+	if len(b) == 0 {
+		return tb
+	}
+	rel := make([]float64, len(b))
+	var pct float64
+	for i, o := range b {
+		rel[i] = o.rel
+		pct += o.pct
+	}
+	m, se := util.MeanSE(rel)
+	tb.MeanPercentile = pct / float64(len(b))
+	tb.ScoreDelta = m - overall
+	tb.StdErr = se
+	return tb
+}
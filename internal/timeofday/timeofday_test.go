@@ -0,0 +1,42 @@
+package timeofday
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"refleks/internal/history"
+)
+
+func TestAnalyze(t *testing.T) {
+	// Play at 10:00 and 19:00 every day for 30 days; evening runs score 10% higher.
+	var runs []history.Run
+	day := time.Date(2025, 9, 1, 0, 0, 0, 0, time.Local)
+	for d := 0; d < 30; d++ {
+		for _, h := range []int{10, 19} {
+			score := 1000.0 + float64(d%3)
+			if h == 19 {
+				score *= 1.1
+			}
+			runs = append(runs, history.Run{ID: fmt.Sprint(d, h), Scenario: "a", End: day.AddDate(0, 0, d).Add(time.Duration(h) * time.Hour), Score: score})
+		}
+	}
+	got := Analyze(runs)
+	if !got.Sufficient || got.Runs != 60 || len(got.ByHour) != 24 || len(got.ByWeekday) != 7 {
+		t.Fatalf("unexpected shape: %+v", got)
+	}
+	if !got.ByHour[19].Sufficient || got.ByHour[18].Sufficient || got.ByHour[19].MeanPercentile <= got.ByHour[10].MeanPercentile {
+		t.Fatalf("unexpected hour buckets: %+v %+v", got.ByHour[10], got.ByHour[19])
+	}
+	if got.BestWindow == nil || got.BestWindow.StartHour > 19 || got.BestWindow.StartHour < 17 || got.BestWindow.TStat < 2 {
+		t.Fatalf("expected an evening best window, got %+v", got.BestWindow)
+	}
+	if math.Abs(got.BestWindow.ScoreDelta-got.ByHour[19].ScoreDelta) > 1e-9 {
+		t.Fatalf("window delta %v should match the 19:00 bucket %v", got.BestWindow.ScoreDelta, got.ByHour[19].ScoreDelta)
+	}
+
+	if few := Analyze(runs[:20]); few.Sufficient || few.Reason == "" {
+		t.Fatalf("too few runs should be reported explicitly: %+v", few)
+	}
+}
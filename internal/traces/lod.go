@@ -0,0 +1,91 @@
+package traces
+
+import (
+	"sort"
+	"time"
+
+	"refleks/internal/models"
+)
+
+// Window returns the samples between from and to seconds after a trace's first
+// sample. A non-positive to means the end of the trace.
+func Window(trace []models.MousePoint, from, to float64) []models.MousePoint {
+	if len(trace) == 0 {
+		return trace
+	}
+	t0 := trace[0].TS
+	lo := 0
+	if from > 0 {
+		start := t0.Add(seconds(from))
+		lo = sort.Search(len(trace), func(i int) bool { return !trace[i].TS.Before(start) })
+	}
+	hi := len(trace)
+	if to > 0 {
+		end := t0.Add(seconds(to))
+		hi = sort.Search(len(trace), func(i int) bool { return trace[i].TS.After(end) })
+	}
+	if hi < lo {
+		hi = lo
+	}
+	return trace[lo:hi]
+}
+
+// Downsample reduces a trace to at most maxPoints samples while keeping its shape.
+// The trace is split into equal time buckets and each keeps its first and last
+// samples and those at its x and y extremes, so flicks and overshoots survive at
+// any level of detail. A non-positive maxPoints keeps every sample.
+func Downsample(trace []models.MousePoint, maxPoints int) []models.MousePoint {
+	if maxPoints <= 0 || len(trace) <= maxPoints {
+		return trace
+	}
+	// Each bucket keeps up to six samples; tiny budgets fall back to a stride.
+	buckets := maxPoints / 6
+	if buckets == 0 {
+		out := make([]models.MousePoint, 0, maxPoints)
+		for i := 0; i < maxPoints; i++ {
+			out = append(out, trace[i*(len(trace)-1)/max(1, maxPoints-1)])
+		}
+		return out
+	}
+	t0 := trace[0].TS
+	span := trace[len(trace)-1].TS.Sub(t0)
+	width := span/time.Duration(buckets) + 1
+	out := make([]models.MousePoint, 0, maxPoints)
+	for lo := 0; lo < len(trace); {
+		b := trace[lo].TS.Sub(t0) / width
+		hi := lo + 1
+		for hi < len(trace) && trace[hi].TS.Sub(t0)/width == b {
+			hi++
+		}
+		keep := []int{lo, hi - 1}
+		minX, maxX, minY, maxY := lo, lo, lo, lo
+		for i := lo + 1; i < hi; i++ {
+			p := trace[i]
+			if p.X < trace[minX].X {
+				minX = i
+			}
+			if p.X > trace[maxX].X {
+				maxX = i
+			}
+			if p.Y < trace[minY].Y {
+				minY = i
+			}
+			if p.Y > trace[maxY].Y {
+				maxY = i
+			}
+		}
+		keep = append(keep, minX, maxX, minY, maxY)
+		sort.Ints(keep)
+		for j, i := range keep {
+			if j == 0 || i != keep[j-1] {
+				out = append(out, trace[i])
+			}
+		}
+		lo = hi
+	}
+	return out
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
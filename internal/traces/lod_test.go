@@ -0,0 +1,63 @@
+package traces
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDownsample(t *testing.T) {
+	SetBaseDir("../../testdata/traces")
+	defer SetBaseDir("")
+	sd, err := Load("VT 1w3ts Intermediate S5 - Challenge - 2025.10.26-13.12.08 Stats.csv")
+	if err != nil {
+		t.Fatalf("load trace: %v", err)
+	}
+	trace := sd.MouseTrace
+	if len(trace) < 2400 {
+		t.Fatalf("expected a long trace, got %d samples", len(trace))
+	}
+
+	lod := Downsample(trace, 1200)
+	if len(lod) > 1200 || len(lod) < 600 {
+		t.Fatalf("downsampled to %d samples, want at most 1200", len(lod))
+	}
+	if lod[0] != trace[0] || lod[len(lod)-1] != trace[len(trace)-1] {
+		t.Error("downsampling should keep the first and last samples")
+	}
+	extremes := func(s []int32) (int32, int32) {
+		lo, hi := s[0], s[0]
+		for _, v := range s {
+			lo, hi = min(lo, v), max(hi, v)
+		}
+		return lo, hi
+	}
+	var xs, lxs []int32
+	for _, p := range trace {
+		xs = append(xs, p.X)
+	}
+	for i, p := range lod {
+		lxs = append(lxs, p.X)
+		if i > 0 && p.TS.Before(lod[i-1].TS) {
+			t.Fatalf("sample %d out of order", i)
+		}
+	}
+	a, b := extremes(xs)
+	if la, lb := extremes(lxs); la != a || lb != b {
+		t.Errorf("x range %d..%d, want %d..%d", la, lb, a, b)
+	}
+	if got := Downsample(trace, 4); len(got) != 4 || got[3] != trace[len(trace)-1] {
+		t.Errorf("tiny budget gave %d samples", len(got))
+	}
+	if got := Downsample(trace, 0); len(got) != len(trace) {
+		t.Errorf("no budget should keep every sample")
+	}
+
+	w := Window(trace, 10, 20)
+	t0 := trace[0].TS
+	if len(w) == 0 || w[0].TS.Before(t0.Add(10*time.Second)) || w[len(w)-1].TS.After(t0.Add(20*time.Second)) {
+		t.Errorf("window of %d samples out of range", len(w))
+	}
+	if got := Window(trace, 0, 0); len(got) != len(trace) {
+		t.Errorf("open window gave %d samples, want %d", len(got), len(trace))
+	}
+}
@@ -2,11 +2,14 @@ package traces
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"refleks/internal/kinematics"
 	"refleks/internal/models"
+	"refleks/internal/sens"
 	appsettings "refleks/internal/settings"
 )
 
@@ -18,6 +21,8 @@ type ScenarioData struct {
 	ScenarioName string              `json:"scenarioName,omitempty"`
 	DatePlayed   string              `json:"datePlayed,omitempty"`
 	MouseTrace   []models.MousePoint `json:"mouseTrace,omitempty"`
+	// Kinematics caches the motion analysis of MouseTrace, in mouse counts.
+	Kinematics *models.TraceKinematics `json:"kinematics,omitempty"`
 }
 
 // customDir optionally overrides the default traces directory.
@@ -75,13 +80,18 @@ func pathFor(fileName string) (string, error) {
 	return filepath.Join(dir, stem), nil
 }
 
-// Save writes scenario data to disk (overwriting if exists).
+// Save writes scenario data to disk (overwriting if exists), analysing the trace
+// first when its kinematics are missing or stale.
 func Save(sd ScenarioData) error {
 	path, err := pathFor(sd.FileName)
 	if err != nil {
 		return err
 	}
 	sd.Version = 1
+	if len(sd.MouseTrace) > 0 && (sd.Kinematics == nil || sd.Kinematics.Version != kinematics.Version) {
+		k := kinematics.Analyze(sd.MouseTrace, sens.Counts)
+		sd.Kinematics = &k
+	}
 	b, err := json.MarshalIndent(sd, "", "  ")
 	if err != nil {
 		return err
@@ -106,6 +116,26 @@ func Load(fileName string) (ScenarioData, error) {
 	return sd, nil
 }
 
+// LoadKinematics returns the motion analysis of a persisted trace, in mouse counts.
+// Traces saved without it, or by an older analysis, are analysed and saved again.
+func LoadKinematics(fileName string) (models.TraceKinematics, error) {
+	sd, err := Load(fileName)
+	if err != nil {
+		return models.TraceKinematics{}, err
+	}
+	if len(sd.MouseTrace) == 0 {
+		return models.TraceKinematics{}, errors.New("no mouse trace recorded for this run")
+	}
+	if sd.Kinematics != nil && sd.Kinematics.Version == kinematics.Version {
+		return *sd.Kinematics, nil
+	}
+	k := kinematics.Analyze(sd.MouseTrace, sens.Counts)
+	sd.Kinematics = &k
+	// The cache is best effort: a read-only traces directory still gets results.
+	_ = Save(sd)
+	return k, nil
+}
+
 // Exists reports whether a persisted record exists for the given stats file name.
 func Exists(fileName string) bool {
 	path, err := pathFor(fileName)
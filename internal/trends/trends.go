@@ -0,0 +1,323 @@
+package trends
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"refleks/internal/history"
+	"refleks/internal/models"
+	"refleks/internal/util"
+)
+
+// Event names emitted by the watcher when a new finding appears.
+const (
+	EventPlateau    = "PlateauDetected"
+	EventRegression = "RegressionDetected"
+)
+
+// Finding kinds.
+const (
+	KindPlateau    = "plateau"
+	KindRegression = "regression"
+)
+
+const (
+	// PlateauSessions is how many recent sessions without a PB or an upward trend make a plateau.
+	PlateauSessions = 5
+	// minSegment is the fewest sessions on each side of a regression change point.
+	minSegment = 3
+	// regressionT is the T statistic a drop must reach to count as a regression.
+	regressionT = 3.0
+	// regressionDrop is the smallest relative drop reported as a regression.
+	regressionDrop = 0.03
+	// trendT is the T statistic above which recent sessions still count as improving.
+	trendT = 2.0
+)
+
+// setupKeys are the stats settings compared around a regression.
+var setupKeys = []string{"cm/360", "Sens Scale", "Horiz Sens", "DPI", "FOV", "FOVScale", "Resolution"}
+
+type session struct {
+	start time.Time
+	best  float64
+	mean  float64
+	setup map[string]string
+}
+
+// Analyze detects plateaus and regressions for every scenario, sorted by scenario
+// and kind. Scores are aggregated per session so a single bad run is not a regression.
+func Analyze(runs []history.Run, gap time.Duration) []models.TrendFinding {
+	byScenario := make(map[string][]session)
+	for _, sess := range history.GroupSessions(runs, gap) {
+		bySc := make(map[string][]history.Run)
+		for _, r := range sess {
+			bySc[r.Scenario] = append(bySc[r.Scenario], r)
+		}
+		for name, rs := range bySc {
+			byScenario[name] = append(byScenario[name], summarize(rs))
+		}
+	}
+	var out []models.TrendFinding
+	for name, ss := range byScenario {
+		out = append(out, detect(name, ss)...)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Scenario != out[j].Scenario {
+			return out[i].Scenario < out[j].Scenario
+		}
+		return out[i].Kind < out[j].Kind
+	})
+	return out
+}
+
+// AnalyzeScenario detects plateaus and regressions for one scenario from its runs
+// grouped by session, oldest first. It lets a live run re-check only its own scenario.
+func AnalyzeScenario(name string, grouped [][]history.Run) []models.TrendFinding {
+	ss := make([]session, 0, len(grouped))
+	for _, rs := range grouped {
+		if len(rs) > 0 {
+			ss = append(ss, summarize(rs))
+		}
+	}
+	return detect(name, ss)
+}
+
+func summarize(rs []history.Run) session {
+	s := session{start: rs[0].Start, best: math.Inf(-1), setup: make(map[string]string)}
+	var sum float64
+	for _, r := range rs {
+		s.best = math.Max(s.best, r.Score)
+		sum += r.Score
+	}
+	s.mean = sum / float64(len(rs))
+	last := rs[len(rs)-1]
+	for _, k := range setupKeys {
+		if v, ok := last.Stats[k]; ok {
+			s.setup[k] = setupValue(k, v)
+		}
+	}
+	return s
+}
+
+// setupValue formats a setting for comparison; cm/360 is rounded so float noise
+// between identical settings is not reported as a change.
+func setupValue(key string, v any) string {
+	if key == "cm/360" {
+		return strconv.FormatFloat(math.Round(util.ToFloat(v)*10)/10, 'f', 1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// detect returns the plateau and regression findings for one scenario's sessions,
+// oldest first.
+func detect(name string, ss []session) []models.TrendFinding {
+	var out []models.TrendFinding
+	if f, ok := plateau(name, ss); ok {
+		out = append(out, f)
+	}
+	if f, ok := regression(name, ss); ok {
+		out = append(out, f)
+	}
+	return out
+}
+
+// plateau reports when at least PlateauSessions sessions have passed since the last
+// personal best without a significant upward trend in session means. The plateau
+// starts after the session that set the best, so it keeps its start while it lasts.
+func plateau(name string, ss []session) (models.TrendFinding, bool) {
+	last := -1
+	before := math.Inf(-1)
+	for i, s := range ss {
+		if s.best > before {
+			before, last = s.best, i
+		}
+	}
+	window := ss[last+1:]
+	if last < 0 || len(window) < PlateauSessions || before <= 0 {
+		return models.TrendFinding{}, false
+	}
+	after := math.Inf(-1)
+	for _, s := range window {
+		after = math.Max(after, s.best)
+	}
+	t := slopeT(means(window))
+	if t >= trendT {
+		return models.TrendFinding{}, false
+	}
+	return models.TrendFinding{
+		Scenario: name,
+		Kind:     KindPlateau,
+		Since:    window[0].start.Format(time.RFC3339),
+		Sessions: len(window),
+		Before:   before,
+		After:    after,
+		Change:   after/before - 1,
+		TStat:    t,
+	}, true
+}
+
+// regression finds the split of session means with the most significant ongoing drop.
+func regression(name string, ss []session) (models.TrendFinding, bool) {
+	best := models.TrendFinding{}
+	split := -1
+	for k := minSegment; k <= len(ss)-minSegment; k++ {
+		mb, seb := util.MeanSE(means(ss[:k]))
+		ma, sea := util.MeanSE(means(ss[k:]))
+		se := math.Hypot(seb, sea)
+		if mb <= 0 || se == 0 {
+			continue
+		}
+		t := (ma - mb) / se
+		if t > -regressionT || ma/mb-1 > -regressionDrop {
+			continue
+		}
+		if split < 0 || t < best.TStat {
+			split = k
+			best = models.TrendFinding{
+				Scenario: name,
+				Kind:     KindRegression,
+				Since:    ss[k].start.Format(time.RFC3339),
+				Sessions: len(ss) - k,
+				Before:   mb,
+				After:    ma,
+				Change:   ma/mb - 1,
+				TStat:    t,
+			}
+		}
+	}
+	if split < 0 {
+		return models.TrendFinding{}, false
+	}
+	best.SetupChanges = setupChanges(ss[max(0, split-minSegment):split], ss[split:min(len(ss), split+minSegment)])
+	return best, true
+}
+
+// setupChanges compares the most common value of each setting on either side of a change.
+func setupChanges(before, after []session) []models.SetupChange {
+	var out []models.SetupChange
+	for _, k := range setupKeys {
+		b, a := mode(before, k), mode(after, k)
+		if b != "" && a != "" && b != a {
+			out = append(out, models.SetupChange{Key: k, Before: b, After: a})
+		}
+	}
+	return out
+}
+
+func mode(ss []session, key string) string {
+	counts := make(map[string]int)
+	best := ""
+	for _, s := range ss {
+		v, ok := s.setup[key]
+		if !ok {
+			continue
+		}
+		counts[v]++
+		if best == "" || counts[v] > counts[best] {
+			best = v
+		}
+	}
+	return best
+}
+
+func means(ss []session) []float64 {
+	out := make([]float64, len(ss))
+	for i, s := range ss {
+		out[i] = s.mean
+	}
+	return out
+}
+
+// slopeT returns the T statistic of the least-squares slope of ys against their index.
+func slopeT(ys []float64) float64 {
+	n := float64(len(ys))
+	if n < 3 {
+		return 0
+	}
+	var sx, sy float64
+	for i, y := range ys {
+		sx += float64(i)
+		sy += y
+	}
+	mx, my := sx/n, sy/n
+	var sxx, sxy float64
+	for i, y := range ys {
+		dx := float64(i) - mx
+		sxx += dx * dx
+		sxy += dx * (y - my)
+	}
+	b := sxy / sxx
+	var ssRes float64
+	for i, y := range ys {
+		e := y - (my + b*(float64(i)-mx))
+		ssRes += e * e
+	}
+	se := math.Sqrt(ssRes/(n-2)) / math.Sqrt(sxx)
+	if se == 0 {
+		if b > 0 {
+			return math.Inf(1)
+		}
+		return 0
+	}
+	return b / se
+}
+
+// Tracker remembers which scenarios currently have a plateau or regression so an
+// event is raised when one appears, not again while it persists.
+// It is safe for concurrent use.
+type Tracker struct {
+	mu     sync.Mutex
+	active map[string]struct{}
+}
+
+// NewTracker returns a tracker with no active findings.
+func NewTracker() *Tracker {
+	return &Tracker{active: make(map[string]struct{})}
+}
+
+// Update replaces the active findings and returns those that were not active before.
+func (t *Tracker) Update(findings []models.TrendFinding) []models.TrendFinding {
+	next := make(map[string]struct{}, len(findings))
+	var out []models.TrendFinding
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, f := range findings {
+		k := findingKey(f.Scenario, f.Kind)
+		next[k] = struct{}{}
+		if _, ok := t.active[k]; !ok {
+			out = append(out, f)
+		}
+	}
+	t.active = next
+	return out
+}
+
+// UpdateScenario replaces the active findings of one scenario, leaving the others as
+// they are, and returns those that were not active before.
+func (t *Tracker) UpdateScenario(name string, findings []models.TrendFinding) []models.TrendFinding {
+	var out []models.TrendFinding
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	was := make(map[string]bool, 2)
+	for _, kind := range []string{KindPlateau, KindRegression} {
+		k := findingKey(name, kind)
+		_, was[k] = t.active[k]
+		delete(t.active, k)
+	}
+	for _, f := range findings {
+		k := findingKey(f.Scenario, f.Kind)
+		t.active[k] = struct{}{}
+		if !was[k] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func findingKey(scenario, kind string) string {
+	return scenario + "\x00" + kind
+}
@@ -0,0 +1,117 @@
+package trends
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"refleks/internal/history"
+	"refleks/internal/models"
+)
+
+// daily builds one session per day of three runs each, scored by score(day, run),
+// with the FOV given by fov(day).
+func daily(days int, score func(d, i int) float64, fov func(d int) string) []history.Run {
+	var runs []history.Run
+	start := time.Date(2025, 9, 1, 18, 0, 0, 0, time.UTC)
+	for d := 0; d < days; d++ {
+		for i := 0; i < 3; i++ {
+			end := start.AddDate(0, 0, d).Add(time.Duration(i+1) * 2 * time.Minute)
+			runs = append(runs, history.Run{
+				ID:       fmt.Sprintf("%d-%d", d, i),
+				Scenario: "VT Ground Intermediate S5",
+				Start:    end.Add(-time.Minute),
+				End:      end,
+				Score:    score(d, i),
+				Stats:    map[string]any{"FOV": fov(d), "cm/360": 35.0},
+			})
+		}
+	}
+	return runs
+}
+
+func TestRegressionWithSetupChange(t *testing.T) {
+	runs := daily(10, func(d, i int) float64 {
+		s := 1000 + float64(i*5+d%2*3)
+		if d >= 5 {
+			s -= 80
+		}
+		return s
+	}, func(d int) string {
+		if d >= 5 {
+			return "110"
+		}
+		return "103"
+	})
+	got := Analyze(runs, 15*time.Minute)
+	var f models.TrendFinding
+	for _, g := range got {
+		if g.Kind == KindRegression {
+			f = g
+		}
+	}
+	if f.Kind == "" {
+		t.Fatalf("expected a regression, got %+v", got)
+	}
+	if f.Since != "2025-09-06T18:01:00Z" || f.Sessions != 5 || f.Change > -0.05 {
+		t.Errorf("unexpected regression %+v", f)
+	}
+	if len(f.SetupChanges) != 1 || f.SetupChanges[0].Key != "FOV" || f.SetupChanges[0].Before != "103" || f.SetupChanges[0].After != "110" {
+		t.Errorf("expected the FOV change to be reported, got %+v", f.SetupChanges)
+	}
+}
+
+func TestPlateau(t *testing.T) {
+	fov := func(int) string { return "103" }
+	flat := func(d, i int) float64 {
+		if d < 3 {
+			return 900 + float64(d*40+i)
+		}
+		return 960 + float64((d+i)%3*5)
+	}
+	got := Analyze(daily(9, flat, fov), 15*time.Minute)
+	if len(got) != 1 || got[0].Kind != KindPlateau || got[0].Sessions < PlateauSessions {
+		t.Fatalf("expected a plateau, got %+v", got)
+	}
+
+	rising := func(d, i int) float64 { return 900 + float64(d*20+i) }
+	if got := Analyze(daily(9, rising, fov), 15*time.Minute); len(got) != 0 {
+		t.Errorf("steady improvement should have no findings, got %+v", got)
+	}
+}
+
+func TestTrackerReportsOnlyNewFindings(t *testing.T) {
+	fov := func(int) string { return "103" }
+	flat := func(d, i int) float64 { return 1000 - float64(i) }
+	tr := NewTracker()
+	if n := tr.Update(Analyze(daily(7, flat, fov), 15*time.Minute)); len(n) != 1 {
+		t.Fatalf("first plateau should be new, got %+v", n)
+	}
+	if n := tr.Update(Analyze(daily(8, flat, fov), 15*time.Minute)); len(n) != 0 {
+		t.Errorf("an ongoing plateau should not be reported again, got %+v", n)
+	}
+}
+
+func TestUpdateScenarioKeepsOtherScenarios(t *testing.T) {
+	fov := func(int) string { return "103" }
+	flat := func(d, i int) float64 { return 1000 - float64(i) }
+	runs := daily(7, flat, fov)
+	other := models.TrendFinding{Scenario: "Other", Kind: KindRegression}
+	tr := NewTracker()
+	tr.Update([]models.TrendFinding{other})
+
+	grouped := history.GroupSessions(runs, 15*time.Minute)
+	found := AnalyzeScenario("VT Ground Intermediate S5", grouped)
+	if want := Analyze(runs, 15*time.Minute); len(found) != len(want) || found[0].Kind != want[0].Kind {
+		t.Fatalf("single-scenario analysis %+v differs from the full one %+v", found, want)
+	}
+	if n := tr.UpdateScenario("VT Ground Intermediate S5", found); len(n) != 1 {
+		t.Fatalf("first plateau should be new, got %+v", n)
+	}
+	if n := tr.UpdateScenario("VT Ground Intermediate S5", found); len(n) != 0 {
+		t.Errorf("an ongoing plateau should not be reported again, got %+v", n)
+	}
+	if n := tr.Update([]models.TrendFinding{other}); len(n) != 0 {
+		t.Errorf("updating one scenario should keep the other scenario's finding, got %+v", n)
+	}
+}
@@ -0,0 +1,9 @@
+package util
+
+import "time"
+
+// StartOfDay returns local midnight of t's day.
+func StartOfDay(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,38 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+)
+
+// LoadJSON decodes the JSON file at path into v. A missing file leaves v untouched
+// and is not an error, so stores start empty on first run.
+func LoadJSON(path string, v any) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// SaveJSON writes v to path as indented JSON. It writes a temp file and renames it
+// over path, so a crash mid-write never leaves a truncated file behind.
+func SaveJSON(path string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
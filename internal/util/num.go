@@ -0,0 +1,56 @@
+package util
+
+import (
+	"math"
+	"sort"
+)
+
+// Median returns the middle value of vals, or 0 when empty. vals is not modified.
+func Median(vals []float64) float64 {
+	if len(vals) == 0 {
+		return 0
+	}
+	s := append([]float64(nil), vals...)
+	sort.Float64s(s)
+	mid := len(s) / 2
+	if len(s)%2 == 1 {
+		return s[mid]
+	}
+	return (s[mid-1] + s[mid]) / 2
+}
+
+// MeanSE returns the mean of vals and its standard error.
+func MeanSE(vals []float64) (mean, se float64) {
+	n := float64(len(vals))
+	if n == 0 {
+		return 0, 0
+	}
+	for _, v := range vals {
+		mean += v
+	}
+	mean /= n
+	if n < 2 {
+		return mean, 0
+	}
+	var ss float64
+	for _, v := range vals {
+		ss += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(ss/(n-1)) / math.Sqrt(n)
+}
+
+// LinReg fits y = a + b*x by ordinary least squares. The slope is 0 when all xs are equal.
+func LinReg(xs, ys []float64) (a, b float64) {
+	n := float64(len(xs))
+	var sx, sy, sxy, sxx float64
+	for i := range xs {
+		sx += xs[i]
+		sy += ys[i]
+		sxy += xs[i] * ys[i]
+		sxx += xs[i] * xs[i]
+	}
+	if den := n*sxx - sx*sx; den != 0 {
+		b = (n*sxy - sx*sy) / den
+	}
+	return (sy - b*sx) / n, b
+}
@@ -0,0 +1,134 @@
+package versions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"refleks/internal/history"
+	"refleks/internal/models"
+)
+
+// Boundary kinds.
+const (
+	KindHash = "hash"
+	KindGame = "game"
+)
+
+// EventName is emitted with a models.VersionBoundary when a run is played on a new
+// hash of its scenario.
+const EventName = "ScenarioVersionChanged"
+
+const (
+	hashKey = "Hash"
+	gameKey = "Game Version"
+)
+
+// Hash returns the scenario hash a run was played on, or "" when unknown.
+func Hash(r history.Run) string {
+	return value(r, hashKey)
+}
+
+func value(r history.Run, key string) string {
+	v, ok := r.Stats[key]
+	if !ok || v == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprint(v))
+}
+
+// Lineage returns the hash and game version history of one scenario from its runs,
+// oldest first. Runs without a value extend the span before them.
+func Lineage(name string, runs []history.Run) models.ScenarioLineage {
+	out := models.ScenarioLineage{Scenario: name, Boundaries: []models.VersionBoundary{}}
+	hashes, games := []models.VersionSpan{}, []models.VersionSpan{}
+	for _, r := range runs {
+		if b, ok := add(&hashes, r, KindHash, value(r, hashKey)); ok {
+			out.Boundaries = append(out.Boundaries, b)
+		}
+		if b, ok := add(&games, r, KindGame, value(r, gameKey)); ok {
+			out.Boundaries = append(out.Boundaries, b)
+		}
+	}
+	for _, list := range [][]models.VersionSpan{hashes, games} {
+		if len(list) > 0 {
+			list[len(list)-1].Current = true
+		}
+	}
+	out.Hashes, out.GameVersions = hashes, games
+	return out
+}
+
+// Boundaries returns the hash and game version boundaries of one scenario's runs,
+// oldest first.
+func Boundaries(name string, runs []history.Run) []models.VersionBoundary {
+	return Lineage(name, runs).Boundaries
+}
+
+// Current returns the hash of every scenario's latest run that reports one. Runs
+// must be oldest first.
+func Current(runs []history.Run) map[string]string {
+	out := make(map[string]string)
+	for _, r := range runs {
+		if h := Hash(r); h != "" {
+			out[r.Scenario] = h
+		}
+	}
+	return out
+}
+
+// Changed reports whether r is the first run on a new hash of its scenario, given
+// the scenario's earlier runs, oldest first.
+func Changed(prev []history.Run, r history.Run) (models.VersionBoundary, bool) {
+	h := Hash(r)
+	if h == "" {
+		return models.VersionBoundary{}, false
+	}
+	for i := len(prev) - 1; i >= 0; i-- {
+		p := Hash(prev[i])
+		if p == "" {
+			continue
+		}
+		if p == h {
+			return models.VersionBoundary{}, false
+		}
+		return boundary(r, KindHash, p, h), true
+	}
+	return models.VersionBoundary{}, false
+}
+
+func boundary(r history.Run, kind, before, after string) models.VersionBoundary {
+	return models.VersionBoundary{
+		Scenario: r.Scenario,
+		Kind:     kind,
+		RunID:    r.ID,
+		At:       r.End.Format(time.RFC3339),
+		Before:   before,
+		After:    after,
+	}
+}
+
+// add extends the last span with r, or starts a new one when r reports a different
+// value, returning the boundary crossed.
+func add(list *[]models.VersionSpan, r history.Run, kind, v string) (models.VersionBoundary, bool) {
+	spans := *list
+	if v == "" && len(spans) == 0 {
+		return models.VersionBoundary{}, false
+	}
+	var b models.VersionBoundary
+	crossed := false
+	if v != "" && (len(spans) == 0 || spans[len(spans)-1].Value != v) {
+		if len(spans) > 0 {
+			b, crossed = boundary(r, kind, spans[len(spans)-1].Value, v), true
+		}
+		spans = append(spans, models.VersionSpan{Value: v, First: r.End.Format(time.RFC3339)})
+	}
+	s := &spans[len(spans)-1]
+	s.Last = r.End.Format(time.RFC3339)
+	s.Runs++
+	if s.Runs == 1 || r.Score > s.BestScore {
+		s.BestScore = r.Score
+	}
+	*list = spans
+	return b, crossed
+}
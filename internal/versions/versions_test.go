@@ -0,0 +1,51 @@
+package versions
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"refleks/internal/history"
+)
+
+func TestLineage(t *testing.T) {
+	start := time.Date(2025, 10, 1, 18, 0, 0, 0, time.UTC)
+	var runs []history.Run
+	play := func(score float64, hash, game string) {
+		stats := map[string]any{}
+		if hash != "" {
+			stats["Hash"] = hash
+		}
+		if game != "" {
+			stats["Game Version"] = game
+		}
+		runs = append(runs, history.Run{ID: fmt.Sprint(len(runs)), Scenario: "A", End: start.Add(time.Duration(len(runs)) * time.Hour), Score: score, Stats: stats})
+	}
+	play(100, "h1", "3.7.9")
+	play(120, "", "3.7.9") // older stats file without a hash
+	play(110, "h1", "3.7.10")
+	play(90, "h2", "3.7.10")
+	play(95, "h2", "3.7.10")
+
+	l := Lineage("A", runs)
+	if len(l.Hashes) != 2 || l.Hashes[0].Runs != 3 || l.Hashes[0].BestScore != 120 || l.Hashes[0].Current || !l.Hashes[1].Current {
+		t.Errorf("unexpected hash spans %+v", l.Hashes)
+	}
+	if len(l.GameVersions) != 2 || l.GameVersions[1].Value != "3.7.10" || l.GameVersions[1].Runs != 3 {
+		t.Errorf("unexpected game version spans %+v", l.GameVersions)
+	}
+	if len(l.Boundaries) != 2 || l.Boundaries[0].Kind != KindGame || l.Boundaries[0].RunID != "2" ||
+		l.Boundaries[1].Kind != KindHash || l.Boundaries[1].Before != "h1" || l.Boundaries[1].After != "h2" {
+		t.Errorf("unexpected boundaries %+v", l.Boundaries)
+	}
+
+	if cur := Current(runs); cur["A"] != "h2" {
+		t.Errorf("current hash = %q, want h2", cur["A"])
+	}
+	if b, ok := Changed(runs[:3], runs[3]); !ok || b.Before != "h1" || b.After != "h2" {
+		t.Errorf("expected a hash change, got %+v %v", b, ok)
+	}
+	if _, ok := Changed(runs[:4], runs[4]); ok {
+		t.Error("a second run on the same hash is not a change")
+	}
+}
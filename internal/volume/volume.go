@@ -0,0 +1,150 @@
+package volume
+
+import (
+	"sort"
+	"time"
+
+	"refleks/internal/history"
+	"refleks/internal/models"
+	"refleks/internal/util"
+)
+
+const dateLayout = "2006-01-02"
+
+type bucket struct {
+	seconds   float64
+	runs      int
+	scenarios map[string]struct{}
+	days      map[string]struct{}
+}
+
+func (b *bucket) add(r history.Run) {
+	if d := r.End.Sub(r.Start).Seconds(); d > 0 {
+		b.seconds += d
+	}
+	b.runs++
+	b.scenarios[r.Scenario] = struct{}{}
+	b.days[r.End.Local().Format(dateLayout)] = struct{}{}
+}
+
+func newBucket() *bucket {
+	return &bucket{scenarios: make(map[string]struct{}), days: make(map[string]struct{})}
+}
+
+// weekStart returns the Monday of t's week.
+func weekStart(t time.Time) time.Time {
+	d := util.StartOfDay(t)
+	return d.AddDate(0, 0, -((int(d.Weekday()) + 6) % 7))
+}
+
+// Analyze sums playtime (each run's scenario window), runs and distinct scenarios per
+// local day and week, and finds the current and longest daily streaks.
+func Analyze(runs []history.Run, now time.Time) models.PracticeVolume {
+	days := make(map[string]*bucket)
+	weeks := make(map[string]*bucket)
+	total := newBucket()
+	for _, r := range runs {
+		dk := r.End.Local().Format(dateLayout)
+		wk := weekStart(r.End).Format(dateLayout)
+		if days[dk] == nil {
+			days[dk] = newBucket()
+		}
+		if weeks[wk] == nil {
+			weeks[wk] = newBucket()
+		}
+		days[dk].add(r)
+		weeks[wk].add(r)
+		total.add(r)
+	}
+	out := models.PracticeVolume{
+		TotalSeconds:  total.seconds,
+		TotalRuns:     total.runs,
+		Scenarios:     len(total.scenarios),
+		DaysPractised: len(days),
+	}
+	levels := levelFunc(days)
+	for k, b := range days {
+		out.Days = append(out.Days, models.DayActivity{Date: k, Seconds: b.seconds, Runs: b.runs, Scenarios: len(b.scenarios), Level: levels(b.seconds)})
+	}
+	sort.Slice(out.Days, func(i, j int) bool { return out.Days[i].Date < out.Days[j].Date })
+	for k, b := range weeks {
+		out.Weeks = append(out.Weeks, models.WeekActivity{Week: k, Seconds: b.seconds, Runs: b.runs, Scenarios: len(b.scenarios), Days: len(b.days)})
+	}
+	sort.Slice(out.Weeks, func(i, j int) bool { return out.Weeks[i].Week < out.Weeks[j].Week })
+
+	streak := 0
+	var prev time.Time
+	for _, d := range out.Days {
+		day, _ := time.ParseInLocation(dateLayout, d.Date, time.Local)
+		if streak > 0 && prev.AddDate(0, 0, 1).Equal(day) {
+			streak++
+		} else {
+			streak = 1
+		}
+		prev = day
+		out.LongestStreak = max(out.LongestStreak, streak)
+	}
+	today := util.StartOfDay(now)
+	if streak > 0 && (prev.Equal(today) || prev.AddDate(0, 0, 1).Equal(today)) {
+		out.CurrentStreak = streak
+	}
+	return out
+}
+
+// Heatmap returns one entry per local day for the given number of days ending today,
+// including days without practice, for a calendar heatmap.
+func Heatmap(runs []history.Run, days int, now time.Time) []models.DayActivity {
+	if days <= 0 {
+		return nil
+	}
+	today := util.StartOfDay(now)
+	first := today.AddDate(0, 0, -(days - 1))
+	var in []history.Run
+	for _, r := range runs {
+		if !r.End.Before(first) && r.End.Before(today.AddDate(0, 0, 1)) {
+			in = append(in, r)
+		}
+	}
+	byDate := make(map[string]models.DayActivity)
+	for _, d := range Analyze(in, now).Days {
+		byDate[d.Date] = d
+	}
+	out := make([]models.DayActivity, 0, days)
+	for d := first; !d.After(today); d = d.AddDate(0, 0, 1) {
+		k := d.Format(dateLayout)
+		if a, ok := byDate[k]; ok {
+			out = append(out, a)
+		} else {
+			out = append(out, models.DayActivity{Date: k})
+		}
+	}
+	return out
+}
+
+// levelFunc maps a day's playtime to a 1..4 intensity by quartile of practised days.
+func levelFunc(days map[string]*bucket) func(float64) int {
+	secs := make([]float64, 0, len(days))
+	for _, b := range days {
+		secs = append(secs, b.seconds)
+	}
+	sort.Float64s(secs)
+	q := func(p float64) float64 {
+		if len(secs) == 0 {
+			return 0
+		}
+		return secs[int(p*float64(len(secs)-1))]
+	}
+	q1, q2, q3 := q(0.25), q(0.5), q(0.75)
+	return func(s float64) int {
+		switch {
+		case s > q3:
+			return 4
+		case s > q2:
+			return 3
+		case s > q1:
+			return 2
+		default:
+			return 1
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package volume
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"refleks/internal/history"
+)
+
+func TestAnalyze(t *testing.T) {
+	// Wednesday 8 October 2025.
+	now := time.Date(2025, 10, 8, 12, 0, 0, 0, time.Local)
+	var runs []history.Run
+	play := func(month time.Month, day int, scenario string, minutes int) {
+		end := time.Date(2025, month, day, 18, len(runs), 0, 0, time.Local)
+		runs = append(runs, history.Run{ID: fmt.Sprint(len(runs)), Scenario: scenario, Start: end.Add(-time.Duration(minutes) * time.Minute), End: end})
+	}
+	// Three days in a row, a gap, then yesterday and the day before.
+	play(9, 29, "A", 1)
+	play(9, 29, "B", 1)
+	play(9, 30, "A", 1)
+	play(10, 1, "A", 2)
+	play(10, 6, "A", 1)
+	play(10, 7, "B", 3)
+
+	v := Analyze(runs, now)
+	if v.TotalRuns != 6 || v.TotalSeconds != 9*60 || v.Scenarios != 2 || v.DaysPractised != 5 {
+		t.Errorf("unexpected totals %+v", v)
+	}
+	if v.LongestStreak != 3 || v.CurrentStreak != 2 {
+		t.Errorf("streaks = current %d longest %d, want 2 and 3", v.CurrentStreak, v.LongestStreak)
+	}
+	if len(v.Weeks) != 2 || v.Weeks[0].Week != "2025-09-29" || v.Weeks[0].Runs != 4 || v.Weeks[0].Days != 3 {
+		t.Errorf("unexpected weeks %+v", v.Weeks)
+	}
+	if v.Days[0].Scenarios != 2 || v.Days[len(v.Days)-1].Level != 4 {
+		t.Errorf("unexpected days %+v", v.Days)
+	}
+
+	if later := Analyze(runs, now.AddDate(0, 0, 2)); later.CurrentStreak != 0 {
+		t.Errorf("a missed day should end the current streak, got %d", later.CurrentStreak)
+	}
+
+	hm := Heatmap(runs, 14, now)
+	if len(hm) != 14 || hm[0].Date != "2025-09-25" || hm[13].Date != "2025-10-08" {
+		t.Fatalf("unexpected heatmap range %v..%v (%d days)", hm[0].Date, hm[len(hm)-1].Date, len(hm))
+	}
+	if hm[4].Runs != 2 || hm[13].Runs != 0 || hm[13].Level != 0 {
+		t.Errorf("unexpected heatmap days %+v / %+v", hm[4], hm[13])
+	}
+}
@@ -0,0 +1,610 @@
+package watcher
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"refleks/internal/anomaly"
+	"refleks/internal/benchmarks"
+	"refleks/internal/compare"
+	"refleks/internal/fatigue"
+	"refleks/internal/filters"
+	"refleks/internal/forecast"
+	"refleks/internal/goals"
+	"refleks/internal/history"
+	"refleks/internal/kinematics"
+	"refleks/internal/models"
+	"refleks/internal/parser"
+	"refleks/internal/pb"
+	"refleks/internal/rating"
+	"refleks/internal/routines"
+	"refleks/internal/sens"
+	"refleks/internal/sensitivity"
+	"refleks/internal/sessionlen"
+	"refleks/internal/sessions"
+	"refleks/internal/setup"
+	"refleks/internal/timeofday"
+	"refleks/internal/traces"
+	"refleks/internal/trends"
+	"refleks/internal/util"
+	"refleks/internal/versions"
+	"refleks/internal/volume"
+)
+
+// historyRun summarizes a parsed record for the run history.
+func historyRun(rec models.ScenarioRecord) history.Run {
+	info, _ := parser.ParseFilename(rec.FileName)
+	start, end := deriveScenarioWindow(info.DatePlayed, rec.Stats, rec.Events)
+	return history.Run{
+		ID:        rec.FileName,
+		Scenario:  info.ScenarioName,
+		Mode:      info.Mode,
+		Start:     start,
+		End:       end,
+		Score:     util.ToFloat(rec.Stats["Score"]),
+		Stats:     rec.Stats,
+		Hidden:    rec.Hidden,
+		Anomalies: rec.Anomalies,
+	}
+}
+
+func filterRun(r history.Run) filters.Run {
+	return filters.Run{Scenario: r.Scenario, Mode: r.Mode, Duration: r.End.Sub(r.Start)}
+}
+
+func sessionRun(r history.Run) sessions.Run {
+	return sessions.Run{
+		ID:       r.ID,
+		Scenario: r.Scenario,
+		Start:    r.Start,
+		End:      r.End,
+		Score:    r.Score,
+		Accuracy: util.ToFloat(r.Stats["Accuracy"]),
+	}
+}
+
+func pbRun(r history.Run) pb.Run {
+	return pb.Run{ID: r.ID, Scenario: r.Scenario, Played: r.End, Score: r.Score}
+}
+
+// resetSessions regroups t from runs and ends an idle last session without events.
+func resetSessions(t *sessions.Tracker, runs []history.Run) {
+	in := make([]sessions.Run, 0, len(runs))
+	for _, r := range runs {
+		in = append(in, sessionRun(r))
+	}
+	t.Reset(in)
+	_ = t.Expire(time.Now())
+}
+
+// rebuildDerived re-flags anomalies, re-applies the filter rules to the full history
+// and recomputes sessions, personal bests, summaries and trend findings from the
+// visible runs.
+func (w *Watcher) rebuildDerived() {
+	w.mu.RLock()
+	set, t, gap := w.filters, w.sessions, sessionGap(w.cfg)
+	exclude, currentOnly := w.excludeAnomalies, w.currentVersionOnly
+	w.mu.RUnlock()
+
+	all := w.history.All()
+	flags := anomaly.Detect(all)
+	current := versions.Current(all)
+	w.history.SetAnomalies(func(r history.Run) []string { return anomaly.Codes(flags[r.ID]) })
+	w.history.SetHidden(func(r history.Run) bool {
+		if currentOnly {
+			if h := versions.Hash(r); h != "" && h != current[r.Scenario] {
+				return true
+			}
+		}
+		return set.Hidden(filterRun(r)) || exclude && len(r.Anomalies) > 0
+	})
+	visible := w.history.Visible()
+	resetSessions(t, visible)
+	runtime.EventsEmit(w.ctx, sessions.EventReset)
+	in := make([]pb.Run, 0, len(visible))
+	for _, r := range visible {
+		in = append(in, pbRun(r))
+	}
+	w.bests.Rebuild(in)
+	w.analytics.Rebuild(visible)
+	w.trends.Update(trends.Analyze(visible, gap))
+	if _, err := w.goals.Check(visible); err != nil {
+		runtime.LogWarningf(w.ctx, "goals save failed: %v", err)
+	}
+}
+
+// ingestLive folds a newly played run into the derived state, emitting events.
+func (w *Watcher) ingestLive(r history.Run) {
+	if r.Hidden {
+		return
+	}
+	w.mu.RLock()
+	t := w.sessions
+	w.mu.RUnlock()
+	events := t.Add(sessionRun(r))
+	w.emitSessionEvents(events)
+	if best, ok := w.bests.Add(pbRun(r)); ok {
+		runtime.EventsEmit(w.ctx, pb.EventName, best)
+	}
+	w.analytics.Add(r)
+	w.checkFatigue(events)
+	w.checkTrends(r)
+	w.checkGoals(r)
+	w.checkRoutines(r)
+	w.checkVersion(r)
+}
+
+// checkVersion announces a run played on a new hash of its scenario. When analytics
+// are restricted to the current version, the older runs are hidden by a rebuild.
+func (w *Watcher) checkVersion(r history.Run) {
+	prev := w.history.Filter(func(o history.Run) bool {
+		return o.Scenario == r.Scenario && o.ID != r.ID && o.End.Before(r.End)
+	})
+	b, ok := versions.Changed(prev, r)
+	if !ok {
+		return
+	}
+	runtime.EventsEmit(w.ctx, versions.EventName, b)
+	w.mu.RLock()
+	currentOnly := w.currentVersionOnly
+	w.mu.RUnlock()
+	if currentOnly {
+		w.rebuildDerived()
+	}
+}
+
+// checkRoutines reports progress on every routine the latest run counts towards today.
+func (w *Watcher) checkRoutines(r history.Run) {
+	now := time.Now()
+	for _, rt := range w.routines.List() {
+		runs := w.routineRuns(rt)
+		st := routines.Evaluate(rt, runs, now)
+		before := routines.Evaluate(rt, withoutRun(runs, r.ID), now)
+		if st.Progress == before.Progress {
+			continue
+		}
+		runtime.EventsEmit(w.ctx, routines.EventProgress, st)
+		if st.Completed {
+			runtime.EventsEmit(w.ctx, routines.EventCompleted, st)
+		}
+	}
+}
+
+// routineRuns returns the visible runs of a routine's scenarios, oldest first.
+func (w *Watcher) routineRuns(rt models.Routine) []history.Run {
+	want := make(map[string]bool, len(rt.Items))
+	for _, it := range rt.Items {
+		want[it.Scenario] = true
+	}
+	return w.history.Filter(func(r history.Run) bool { return !r.Hidden && want[r.Scenario] })
+}
+
+func withoutRun(runs []history.Run, id string) []history.Run {
+	out := make([]history.Run, 0, len(runs))
+	for _, r := range runs {
+		if r.ID != id {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// checkAnomalies judges a run against the known runs played before it.
+func (w *Watcher) checkAnomalies(r history.Run) []models.AnomalyReason {
+	return anomaly.Check(w.runsBefore(r), r)
+}
+
+// resettleAnomalies rebuilds the derived state when r makes a changed setting the
+// norm, so the runs hidden as anomalies while it was new are shown again.
+func (w *Watcher) resettleAnomalies(r history.Run) {
+	w.mu.RLock()
+	exclude := w.excludeAnomalies
+	w.mu.RUnlock()
+	if exclude && anomaly.Settles(w.runsBefore(r), r) {
+		w.rebuildDerived()
+	}
+}
+
+// runsBefore returns the known runs that ended before r, oldest first.
+func (w *Watcher) runsBefore(r history.Run) []history.Run {
+	return w.history.Filter(func(o history.Run) bool { return o.ID != r.ID && o.End.Before(r.End) })
+}
+
+// checkGoals announces goals the latest run meets.
+func (w *Watcher) checkGoals(r history.Run) {
+	done, err := w.goals.Check([]history.Run{r})
+	if err != nil {
+		runtime.LogWarningf(w.ctx, "goals save failed: %v", err)
+	}
+	for _, g := range done {
+		runtime.EventsEmit(w.ctx, goals.EventName, goals.Evaluate(g, w.history.Scenario(g.Scenario), time.Now()))
+	}
+}
+
+// checkTrends re-runs trend detection for the latest run's scenario and announces
+// plateaus and regressions that were not present before it.
+func (w *Watcher) checkTrends(r history.Run) {
+	w.mu.RLock()
+	t := w.sessions
+	w.mu.RUnlock()
+	found := trends.AnalyzeScenario(r.Scenario, groupBySession(w.history.Scenario(r.Scenario), t.Starts()))
+	for _, f := range w.trends.UpdateScenario(r.Scenario, found) {
+		name := trends.EventPlateau
+		if f.Kind == trends.KindRegression {
+			name = trends.EventRegression
+		}
+		runtime.EventsEmit(w.ctx, name, f)
+	}
+}
+
+// groupBySession splits runs (oldest first) by the session they were played in,
+// given the tracker's session start times, oldest first.
+func groupBySession(runs []history.Run, starts []time.Time) [][]history.Run {
+	var out [][]history.Run
+	cur := -1
+	for _, r := range runs {
+		i := sort.Search(len(starts), func(i int) bool { return starts[i].After(r.Start) }) - 1
+		if i != cur || len(out) == 0 {
+			out = append(out, nil)
+			cur = i
+		}
+		out[len(out)-1] = append(out[len(out)-1], r)
+	}
+	return out
+}
+
+// checkFatigue looks for a decline in the active session the latest run belongs to.
+func (w *Watcher) checkFatigue(events []sessions.Event) {
+	for i := len(events) - 1; i >= 0; i-- {
+		sess := events[i].Session
+		if !sess.Active {
+			continue
+		}
+		runs := make([]history.Run, 0, len(sess.RunIDs))
+		for _, id := range sess.RunIDs {
+			if r, ok := w.history.Get(id); ok {
+				runs = append(runs, r)
+			}
+		}
+		norms := make(map[string]fatigue.Norm)
+		norm := func(name string) fatigue.Norm {
+			n, ok := norms[name]
+			if !ok {
+				n = fatigue.Norms(w.history.Scenario(name))[name]
+				norms[name] = n
+			}
+			return n
+		}
+		if alert, ok := w.fatigue.Check(sess.ID, runs, norm); ok {
+			runtime.EventsEmit(w.ctx, fatigue.EventName, alert)
+		}
+		return
+	}
+}
+
+// emitSessionEvents forwards session lifecycle events to the frontend.
+func (w *Watcher) emitSessionEvents(events []sessions.Event) {
+	for _, ev := range events {
+		runtime.EventsEmit(w.ctx, ev.Name, ev.Session)
+	}
+}
+
+// GetSessions returns up to limit sessions, newest first.
+func (w *Watcher) GetSessions(limit int) []models.Session {
+	w.mu.RLock()
+	t := w.sessions
+	w.mu.RUnlock()
+	return t.Sessions(limit)
+}
+
+// GetPersonalBests returns the current best of every visible scenario.
+func (w *Watcher) GetPersonalBests() []models.PersonalBest {
+	return w.bests.Bests()
+}
+
+// GetPersonalBestHistory returns the PB progression of one scenario, oldest first.
+func (w *Watcher) GetPersonalBestHistory(scenario string) []models.PersonalBest {
+	return w.bests.Timeline(scenario)
+}
+
+// GetScenarioSummary returns aggregate statistics over every visible run of a scenario,
+// with the points where its hash or the game version changed.
+func (w *Watcher) GetScenarioSummary(name string) (models.ScenarioSummary, bool) {
+	sum, ok := w.analytics.Summary(name, time.Now())
+	if ok {
+		sum.VersionBoundaries = versions.Boundaries(name, w.history.Scenario(name))
+	}
+	return sum, ok
+}
+
+// ScenarioLineage returns the hashes and game versions a scenario was played on,
+// over every run including hidden ones.
+func (w *Watcher) ScenarioLineage(name string) models.ScenarioLineage {
+	return versions.Lineage(name, w.history.Filter(func(r history.Run) bool { return r.Scenario == name }))
+}
+
+// PredictHighscore forecasts the next personal best of a scenario from its full history,
+// using the sessions of all visible runs.
+func (w *Watcher) PredictHighscore(name string) models.HighscorePrediction {
+	w.mu.RLock()
+	gap := sessionGap(w.cfg)
+	w.mu.RUnlock()
+	return forecast.Predict(name, w.history.Visible(), gap, time.Now())
+}
+
+// SessionLengthRecommendations recommends a number of attempts per session for every
+// visible scenario, based on sessions grouped from the full history.
+func (w *Watcher) SessionLengthRecommendations() []models.SessionLengthRecommendation {
+	w.mu.RLock()
+	gap := sessionGap(w.cfg)
+	w.mu.RUnlock()
+	return sessionlen.Analyze(w.history.Visible(), gap)
+}
+
+// FatigueProfiles returns the warm-up and fatigue profile of every visible scenario.
+func (w *Watcher) FatigueProfiles() []models.FatigueProfile {
+	w.mu.RLock()
+	gap := sessionGap(w.cfg)
+	w.mu.RUnlock()
+	return fatigue.Analyze(w.history.Visible(), gap)
+}
+
+// SensitivityAnalysis relates cm/360 to detrended performance per scenario and scenario type.
+func (w *Watcher) SensitivityAnalysis() []models.SensitivityAnalysis {
+	return sensitivity.Analyze(w.history.Visible())
+}
+
+// ScenarioSensitivity relates cm/360 to detrended performance for one scenario.
+func (w *Watcher) ScenarioSensitivity(name string) models.SensitivityAnalysis {
+	return sensitivity.AnalyzeScenario(name, w.history.Scenario(name))
+}
+
+// TimeOfDayAnalysis compares normalized performance by hour of day and weekday.
+func (w *Watcher) TimeOfDayAnalysis() models.TimeOfDayAnalysis {
+	return timeofday.Analyze(w.history.Visible())
+}
+
+// TrendFindings returns the current plateaus and regressions of every visible scenario.
+func (w *Watcher) TrendFindings() []models.TrendFinding {
+	w.mu.RLock()
+	gap := sessionGap(w.cfg)
+	w.mu.RUnlock()
+	return trends.Analyze(w.history.Visible(), gap)
+}
+
+// SkillRatings rates every scenario category from the visible runs, using benchmark
+// categories and thresholds learned from progress lookups where available.
+func (w *Watcher) SkillRatings() []models.CategoryRating {
+	return rating.Analyze(w.history.Visible(), benchmarks.LoadIndex())
+}
+
+// Goals returns every goal evaluated against the visible runs, oldest first.
+func (w *Watcher) Goals() ([]models.GoalStatus, error) {
+	now := time.Now()
+	list := w.goals.List()
+	out := make([]models.GoalStatus, 0, len(list))
+	for _, g := range list {
+		out = append(out, goals.Evaluate(g, w.history.Scenario(g.Scenario), now))
+	}
+	return out, w.goals.Err()
+}
+
+// GoalStatus evaluates one goal against the visible runs.
+func (w *Watcher) GoalStatus(id string) (models.GoalStatus, bool) {
+	g, ok := w.goals.Get(id)
+	if !ok {
+		return models.GoalStatus{}, false
+	}
+	return goals.Evaluate(g, w.history.Scenario(g.Scenario), time.Now()), true
+}
+
+// AddGoal stores a new goal. Only runs played from now on can achieve it.
+func (w *Watcher) AddGoal(g models.Goal) (models.Goal, error) {
+	return w.goals.Add(g, time.Now())
+}
+
+// UpdateGoal edits a goal and re-checks it against the runs since it was created.
+func (w *Watcher) UpdateGoal(g models.Goal) error {
+	if err := w.goals.Update(g); err != nil {
+		return err
+	}
+	_, err := w.goals.Check(w.history.Visible())
+	return err
+}
+
+// DeleteGoal removes a goal.
+func (w *Watcher) DeleteGoal(id string) error {
+	return w.goals.Delete(id)
+}
+
+// Routines returns every routine matched against today's runs, oldest first.
+func (w *Watcher) Routines() ([]models.RoutineStatus, error) {
+	now := time.Now()
+	list := w.routines.List()
+	out := make([]models.RoutineStatus, 0, len(list))
+	for _, rt := range list {
+		out = append(out, routines.Evaluate(rt, w.routineRuns(rt), now))
+	}
+	return out, w.routines.Err()
+}
+
+// RoutineStatus evaluates one routine against today's runs.
+func (w *Watcher) RoutineStatus(id string) (models.RoutineStatus, bool) {
+	rt, ok := w.routines.Get(id)
+	if !ok {
+		return models.RoutineStatus{}, false
+	}
+	return routines.Evaluate(rt, w.routineRuns(rt), time.Now()), true
+}
+
+// AddRoutine stores a new routine.
+func (w *Watcher) AddRoutine(rt models.Routine) (models.Routine, error) {
+	return w.routines.Add(rt, time.Now())
+}
+
+// UpdateRoutine edits a routine's name, scenarios and schedule.
+func (w *Watcher) UpdateRoutine(rt models.Routine) error {
+	return w.routines.Update(rt)
+}
+
+// DeleteRoutine removes a routine.
+func (w *Watcher) DeleteRoutine(id string) error {
+	return w.routines.Delete(id)
+}
+
+// PracticeVolume returns playtime, run counts and daily streaks over the visible runs.
+func (w *Watcher) PracticeVolume() models.PracticeVolume {
+	return volume.Analyze(w.history.Visible(), time.Now())
+}
+
+// ActivityHeatmap returns the practice volume of each of the last days days.
+func (w *Watcher) ActivityHeatmap(days int) []models.DayActivity {
+	return volume.Heatmap(w.history.Visible(), days, time.Now())
+}
+
+// RunAnomalies returns every run flagged as a technical anomaly, newest first.
+func (w *Watcher) RunAnomalies() []models.RunAnomaly {
+	w.mu.RLock()
+	exclude := w.excludeAnomalies
+	w.mu.RUnlock()
+	all := w.history.All()
+	flags := anomaly.Detect(all)
+	out := make([]models.RunAnomaly, 0, len(flags))
+	for _, r := range all {
+		reasons, ok := flags[r.ID]
+		if !ok {
+			continue
+		}
+		out = append(out, models.RunAnomaly{
+			ID:       r.ID,
+			Scenario: r.Scenario,
+			Played:   r.End.Format(time.RFC3339),
+			Score:    r.Score,
+			Reasons:  reasons,
+			Excluded: exclude,
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Played > out[j].Played })
+	return out
+}
+
+// SetupImpact splits the visible runs into setup epochs and measures the impact of
+// each setup change per scenario.
+func (w *Watcher) SetupImpact() models.SetupAnalysis {
+	return setup.Analyze(w.history.Visible())
+}
+
+// CompareRuns puts run b side by side with run a: stats, kill pace, setup and traces.
+func (w *Watcher) CompareRuns(idA, idB string) (models.RunComparison, error) {
+	a, err := w.comparisonRun(idA)
+	if err != nil {
+		return models.RunComparison{}, err
+	}
+	b, err := w.comparisonRun(idB)
+	if err != nil {
+		return models.RunComparison{}, err
+	}
+	return compare.Compare(a, b), nil
+}
+
+// FlickAnalysis segments a run's mouse trace into flicks between consecutive kills,
+// measured in unit (counts, deg or cm).
+func (w *Watcher) FlickAnalysis(id, unit string) (models.FlickAnalysis, error) {
+	rec, sc, err := w.scaledTrace(id, unit)
+	if err != nil {
+		return models.FlickAnalysis{}, err
+	}
+	info, _ := parser.ParseFilename(rec.FileName)
+	start, _ := deriveScenarioWindow(info.DatePlayed, rec.Stats, rec.Events)
+	flicks := kinematics.Flicks(rec.MouseTrace, sc, killTimes(rec.Events, info.DatePlayed), start)
+	return models.FlickAnalysis{RunID: rec.FileName, Unit: sc.Unit, Flicks: flicks, Groups: kinematics.GroupFlicks(flicks)}, nil
+}
+
+// TraceKinematics analyses a run's mouse trace in unit. The analysis in counts is
+// cached with a persisted trace; degrees and centimetres depend on the run's settings.
+func (w *Watcher) TraceKinematics(id, unit string) (models.TraceKinematics, error) {
+	if unit == "" || unit == sens.UnitCounts {
+		if traces.Exists(id) {
+			return traces.LoadKinematics(id)
+		}
+		// Traces that could not be saved are only held in memory.
+		pts, err := w.loadTrace(id)
+		if err != nil {
+			return models.TraceKinematics{}, err
+		}
+		return kinematics.Analyze(pts, sens.Counts), nil
+	}
+	rec, sc, err := w.scaledTrace(id, unit)
+	if err != nil {
+		return models.TraceKinematics{}, err
+	}
+	return kinematics.Analyze(rec.MouseTrace, sc), nil
+}
+
+// SmoothnessTrend reports the tremor and smoothness of every visible run of a
+// scenario with a persisted trace, oldest first, from the cached trace analysis.
+func (w *Watcher) SmoothnessTrend(name string) models.SmoothnessTrend {
+	var points []models.SmoothnessPoint
+	for _, r := range w.history.Scenario(name) {
+		if !traces.Exists(r.ID) {
+			continue
+		}
+		k, err := traces.LoadKinematics(r.ID)
+		if err != nil || k.Spectrum.Windows == 0 {
+			continue
+		}
+		points = append(points, models.SmoothnessPoint{
+			RunID:       r.ID,
+			Played:      r.End.Format(time.RFC3339),
+			Score:       r.Score,
+			TremorRatio: k.Spectrum.TremorRatio,
+			Smoothness:  k.Spectrum.Smoothness,
+		})
+	}
+	return kinematics.SmoothnessTrend(name, points)
+}
+
+// ConvertedTrace returns a run's mouse trace in degrees of yaw and pitch or in
+// centimetres of mouse travel, relative to its first sample.
+func (w *Watcher) ConvertedTrace(id, unit string) ([]models.TracePoint, error) {
+	rec, sc, err := w.scaledTrace(id, unit)
+	if err != nil {
+		return nil, err
+	}
+	return sens.Convert(rec.MouseTrace, sc), nil
+}
+
+// scaledTrace loads a run with its mouse trace and the conversion of its counts into unit.
+func (w *Watcher) scaledTrace(id, unit string) (models.ScenarioRecord, sens.Scale, error) {
+	rec, err := w.LoadRecord(id)
+	if err != nil {
+		return models.ScenarioRecord{}, sens.Scale{}, err
+	}
+	if rec.MouseTrace, err = w.loadTrace(id); err != nil {
+		return models.ScenarioRecord{}, sens.Scale{}, err
+	}
+	sc, ok := sens.ScaleFromStats(rec.Stats, unit)
+	if !ok {
+		return models.ScenarioRecord{}, sens.Scale{}, fmt.Errorf("cannot convert this run's trace to %q: sensitivity or DPI unknown", unit)
+	}
+	return rec, sc, nil
+}
+
+func (w *Watcher) comparisonRun(id string) (compare.Run, error) {
+	rec, err := w.LoadRecord(id)
+	if err != nil {
+		return compare.Run{}, err
+	}
+	run := historyRun(rec)
+	info, _ := parser.ParseFilename(rec.FileName)
+	// Runs without a trace are still compared on stats and kill pace.
+	trace, err := w.loadTrace(id)
+	if err != nil && !errors.Is(err, errNoTrace) {
+		return compare.Run{}, err
+	}
+	return compare.Run{Run: run, Kills: killTimes(rec.Events, info.DatePlayed), Trace: trace}, nil
+}
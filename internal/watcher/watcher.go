@@ -1,8 +1,12 @@
 package watcher
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
@@ -12,37 +16,101 @@ import (
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 
+	"refleks/internal/analytics"
+	"refleks/internal/anomaly"
+	"refleks/internal/archive"
 	"refleks/internal/constants"
+	"refleks/internal/fatigue"
+	"refleks/internal/filters"
+	"refleks/internal/goals"
+	"refleks/internal/history"
+	"refleks/internal/killstats"
 	"refleks/internal/models"
 	"refleks/internal/parser"
+	"refleks/internal/pb"
+	"refleks/internal/routines"
 	"refleks/internal/sens"
+	"refleks/internal/sessions"
 	"refleks/internal/traces"
+	"refleks/internal/trends"
 	"refleks/internal/util"
 )
 
+// errNoTrace is returned for runs without a recorded mouse trace.
+var errNoTrace = errors.New("no mouse trace recorded for this run")
+
 // Watcher monitors a directory for new stats files and emits events.
 type Watcher struct {
 	ctx     context.Context
 	cfg     models.WatcherConfig
 	mu      sync.RWMutex
 	running bool
+	// started is set by the first Start, which builds the derived state; setters
+	// called before it only record their value.
+	started bool
 	stopCh  chan struct{}
-	seen    map[string]struct{} // full file path set
-
-	recent []models.ScenarioRecord
-	mouse  MouseProvider
+	seen    map[string]struct{} // stats file name set (stable when files move into archives)
+
+	recent   []models.ScenarioRecord
+	mouse    MouseProvider
+	filters  *filters.Set
+	sessions *sessions.Tracker
+	// excludeAnomalies hides runs flagged as technical anomalies, like filtered runs.
+	excludeAnomalies bool
+	// currentVersionOnly hides runs played on an older hash of their scenario.
+	currentVersionOnly bool
+
+	// history holds every known run; sessions, bests and summaries are derived from it.
+	history   *history.Store
+	bests     *pb.Ledger
+	analytics *analytics.Engine
+	fatigue   *fatigue.Monitor
+	trends    *trends.Tracker
+	goals     *goals.Store
+	routines  *routines.Store
 }
 
 // New returns a new Watcher with the given config.
 func New(ctx context.Context, cfg models.WatcherConfig) *Watcher {
+	h, err := history.Open()
+	if err != nil {
+		runtime.LogWarningf(ctx, "run history unavailable, falling back to memory: %v", err)
+		h = history.NewMemory()
+	}
+	// Goal and routine stores that failed to load are kept: they refuse changes and
+	// report the error to the UI instead of silently dropping them.
+	g, err := goals.Open()
+	if err != nil {
+		runtime.LogWarningf(ctx, "goals unavailable: %v", err)
+	}
+	rt, err := routines.Open()
+	if err != nil {
+		runtime.LogWarningf(ctx, "routines unavailable: %v", err)
+	}
 	return &Watcher{
-		ctx:    ctx,
-		cfg:    cfg,
-		stopCh: make(chan struct{}),
-		seen:   make(map[string]struct{}),
+		ctx:       ctx,
+		cfg:       cfg,
+		stopCh:    make(chan struct{}),
+		seen:      make(map[string]struct{}),
+		sessions:  sessions.NewTracker(sessionGap(cfg)),
+		history:   h,
+		bests:     pb.NewLedger(),
+		analytics: analytics.New(),
+		fatigue:   fatigue.NewMonitor(),
+		trends:    trends.NewTracker(),
+		goals:     g,
+		routines:  rt,
 	}
 }
 
+// sessionGap returns the configured session gap, falling back to the default.
+func sessionGap(cfg models.WatcherConfig) time.Duration {
+	if cfg.SessionGap > 0 {
+		return cfg.SessionGap
+	}
+	return time.Duration(constants.DefaultSessionGapMinutes) * time.Minute
+}
+
 // MouseProvider supplies time-ranged mouse traces for enrichment.
 type MouseProvider interface {
 	Enabled() bool
@@ -56,6 +124,74 @@ func (w *Watcher) SetMouseProvider(p MouseProvider) {
 	w.mouse = p
 }
 
+// SetFilters replaces the scenario filter rules and re-tags records already in memory.
+// A 'ScenarioUpdated' event is emitted for every record whose hidden flag changes.
+// Nothing is rebuilt when the rules are unchanged.
+func (w *Watcher) SetFilters(set *filters.Set) {
+	var toEmit []models.ScenarioRecord
+	w.mu.Lock()
+	if w.filters.Equal(set) {
+		w.mu.Unlock()
+		return
+	}
+	w.filters = set
+	started := w.started
+	for i := range w.recent {
+		rec := w.recent[i]
+		info, err := parser.ParseFilename(rec.FileName)
+		if err != nil {
+			continue
+		}
+		hidden := w.isHiddenLocked(info, rec.Stats, rec.Events)
+		if hidden != rec.Hidden {
+			rec.Hidden = hidden
+			w.recent[i] = rec
+			toEmit = append(toEmit, rec)
+		}
+	}
+	w.mu.Unlock()
+
+	if started {
+		w.rebuildDerived()
+	}
+	for _, rec := range toEmit {
+		runtime.EventsEmit(w.ctx, "ScenarioUpdated", rec)
+	}
+}
+
+// SetAnomalyExclusion sets whether runs flagged as technical anomalies are hidden
+// from derived statistics, and rebuilds them when the setting changes after Start.
+func (w *Watcher) SetAnomalyExclusion(exclude bool) {
+	w.mu.Lock()
+	changed := w.excludeAnomalies != exclude && w.started
+	w.excludeAnomalies = exclude
+	w.mu.Unlock()
+	if changed {
+		w.rebuildDerived()
+	}
+}
+
+// SetCurrentVersionOnly sets whether runs played on an older hash of their scenario
+// are hidden from derived statistics, and rebuilds them when the setting changes after Start.
+func (w *Watcher) SetCurrentVersionOnly(on bool) {
+	w.mu.Lock()
+	changed := w.currentVersionOnly != on && w.started
+	w.currentVersionOnly = on
+	w.mu.Unlock()
+	if changed {
+		w.rebuildDerived()
+	}
+}
+
+// isHiddenLocked evaluates the filter rules for a run. Caller must hold w.mu.
+func (w *Watcher) isHiddenLocked(info parser.FilenameInfo, stats map[string]any, events [][]string) bool {
+	if w.filters == nil {
+		return false
+	}
+	start, end := deriveScenarioWindow(info.DatePlayed, stats, events)
+	return w.filters.Hidden(filters.Run{Scenario: info.ScenarioName, Mode: info.Mode, Duration: end.Sub(start)})
+}
+
 // Start begins polling loop. It is safe to call once; subsequent calls return an error.
 func (w *Watcher) Start() error {
 	w.mu.Lock()
@@ -64,6 +200,7 @@ func (w *Watcher) Start() error {
 		return nil
 	}
 	w.running = true
+	w.started = true
 	w.mu.Unlock()
 
 	// Do not create the directory if it doesn't exist. Just log and continue.
@@ -81,6 +218,8 @@ func (w *Watcher) Start() error {
 	if w.cfg.ParseExistingOnStart {
 		_ = w.scanOnce(true)
 	}
+	// Historical sessions and bests are not announced, only rebuilt.
+	w.rebuildDerived()
 
 	go w.loop()
 	return nil
@@ -115,6 +254,7 @@ func (w *Watcher) loop() {
 			return
 		case <-ticker.C:
 			_ = w.scanOnce(false)
+			w.emitSessionEvents(w.sessions.Expire(time.Now()))
 		}
 	}
 }
@@ -126,11 +266,8 @@ func (w *Watcher) scanOnce(includeAll bool) error {
 		return err
 	}
 	// Build list with parsed timestamps so we can sort by date, not filename
-	type fileRec struct {
-		path string
-		t    time.Time
-	}
 	var files []fileRec
+	onDisk := make(map[string]struct{})
 	for _, e := range entries {
 		if e.IsDir() {
 			continue
@@ -144,39 +281,74 @@ func (w *Watcher) scanOnce(includeAll bool) error {
 		if err != nil {
 			continue
 		}
-		files = append(files, fileRec{path: full, t: info.DatePlayed})
+		files = append(files, fileRec{path: full, name: name, t: info.DatePlayed})
+		onDisk[name] = struct{}{}
+	}
+	// Archived files only change through explicit archive/restore actions on files
+	// already seen, so they only need to be considered on the initial pass.
+	if includeAll {
+		if entries, err := archive.Entries(); err != nil {
+			runtime.LogWarningf(w.ctx, "archive index unavailable: %v", err)
+		} else {
+			for i := range entries {
+				e := entries[i]
+				if _, dup := onDisk[e.FileName]; dup {
+					continue
+				}
+				info, err := parser.ParseFilename(e.FileName)
+				if err != nil {
+					continue
+				}
+				files = append(files, fileRec{path: archive.Path(e), name: e.FileName, t: info.DatePlayed, archived: &e})
+			}
+		}
 	}
 	// Sort by time ascending (oldest first)
 	sort.Slice(files, func(i, j int) bool { return files[i].t.Before(files[j].t) })
+	zips := &archiveCache{}
 	// If includeAll with a limit, restrict to last N files
 	if includeAll && w.cfg.ParseExistingLimit > 0 && len(files) > w.cfg.ParseExistingLimit {
 		// mark older files as seen so we don't parse them later
 		older := files[:len(files)-w.cfg.ParseExistingLimit]
 		w.mu.Lock()
 		for _, fr := range older {
-			w.seen[fr.path] = struct{}{}
+			w.seen[fr.name] = struct{}{}
 		}
 		w.mu.Unlock()
+		// Runs missing from history are still summarized once, without traces.
+		for _, fr := range older {
+			if w.history.Has(fr.name) {
+				continue
+			}
+			rec, err := w.readFile(fr, zips)
+			if err != nil {
+				runtime.LogErrorf(w.ctx, "parse error for %s: %v", fr.path, err)
+				continue
+			}
+			w.history.Add(historyRun(rec))
+		}
 		// keep only the last N files for parsing now
 		files = files[len(files)-w.cfg.ParseExistingLimit:]
 	}
 	for _, fr := range files {
 		full := fr.path
 		w.mu.RLock()
-		_, known := w.seen[full]
+		_, known := w.seen[fr.name]
 		w.mu.RUnlock()
 		if known && !includeAll {
 			continue
 		}
 
-		rec, err := w.parseFile(full)
+		rec, err := w.readFile(fr, zips)
 		if err != nil {
 			runtime.LogErrorf(w.ctx, "parse error for %s: %v", full, err)
 			continue
 		}
+		w.attachTrace(&rec)
+		rec.Anomalies = anomaly.Codes(w.checkAnomalies(historyRun(rec)))
 
 		w.mu.Lock()
-		w.seen[full] = struct{}{}
+		w.seen[fr.name] = struct{}{}
 		w.recent = append(w.recent, rec)
 		cap := w.effectiveRecentCap()
 		if cap > 0 && len(w.recent) > cap {
@@ -186,16 +358,138 @@ func (w *Watcher) scanOnce(includeAll bool) error {
 
 		// Emit a flat ScenarioRecord to simplify the IPC contract.
 		runtime.EventsEmit(w.ctx, "ScenarioAdded", rec)
+
+		run := historyRun(rec)
+		w.mu.RLock()
+		run.Hidden = run.Hidden || w.excludeAnomalies && len(run.Anomalies) > 0
+		w.mu.RUnlock()
+		if !w.history.Has(run.ID) {
+			w.history.Add(run)
+		}
+		// The initial pass is folded in by rebuildDerived instead of replaying events.
+		if !includeAll {
+			w.ingestLive(run)
+			w.resettleAnomalies(run)
+		}
+	}
+	if err := w.history.Flush(); err != nil {
+		runtime.LogWarningf(w.ctx, "failed to persist run history: %v", err)
 	}
 	return nil
 }
 
-func (w *Watcher) parseFile(fullPath string) (models.ScenarioRecord, error) {
+// fileRec is a discovered stats file with its timestamp parsed from the name.
+type fileRec struct {
+	path string
+	name string
+	t    time.Time
+	// archived is set when the file lives in a monthly archive rather than the stats dir.
+	archived *models.ArchiveEntry
+}
+
+// archiveCache holds the contents of the monthly archive read last. Scans visit
+// files oldest first, so each month's zip is opened once.
+type archiveCache struct {
+	name  string
+	files map[string][]byte
+}
+
+func (c *archiveCache) read(e models.ArchiveEntry) ([]byte, error) {
+	if c.files == nil || c.name != e.Archive {
+		files, err := archive.ReadAll(e.Archive)
+		if err != nil {
+			return nil, err
+		}
+		c.name, c.files = e.Archive, files
+	}
+	b, ok := c.files[e.FileName]
+	if !ok {
+		return nil, fmt.Errorf("%s not found in %s", e.FileName, e.Archive)
+	}
+	return b, nil
+}
+
+// readFile parses a stats file from disk or from its archive, without a mouse trace.
+// Archived files are read through zips when set, or on their own otherwise.
+func (w *Watcher) readFile(fr fileRec, zips *archiveCache) (models.ScenarioRecord, error) {
+	if fr.archived != nil {
+		var b []byte
+		var err error
+		if zips != nil {
+			b, err = zips.read(*fr.archived)
+		} else {
+			b, err = archive.Read(*fr.archived)
+		}
+		if err != nil {
+			return models.ScenarioRecord{}, err
+		}
+		return w.parseRecord(fr.path, bytes.NewReader(b))
+	}
+	f, err := os.Open(fr.path)
+	if err != nil {
+		return models.ScenarioRecord{}, err
+	}
+	defer f.Close()
+	return w.parseRecord(fr.path, f)
+}
+
+// LoadRecord returns the record of a run by stats file name. Recent records are
+// served from memory; older ones are re-read from the stats directory or the
+// archive. The mouse trace is not included: load it with loadTrace.
+func (w *Watcher) LoadRecord(id string) (models.ScenarioRecord, error) {
+	w.mu.RLock()
+	for i := len(w.recent) - 1; i >= 0; i-- {
+		if w.recent[i].FileName == id {
+			rec := w.recent[i]
+			w.mu.RUnlock()
+			return rec, nil
+		}
+	}
+	dir := w.cfg.Path
+	w.mu.RUnlock()
+
+	if !isKovaaksStatsFile(id) || filepath.Base(id) != id {
+		return models.ScenarioRecord{}, fmt.Errorf("invalid run id %q", id)
+	}
+	info, err := parser.ParseFilename(id)
+	if err != nil {
+		return models.ScenarioRecord{}, err
+	}
+	fr := fileRec{path: filepath.Join(dir, id), name: id, t: info.DatePlayed}
+	if _, err := os.Stat(fr.path); err != nil {
+		entries, aerr := archive.Entries()
+		if aerr != nil {
+			return models.ScenarioRecord{}, aerr
+		}
+		found := false
+		for i := range entries {
+			if entries[i].FileName == id {
+				fr.path, fr.archived, found = archive.Path(entries[i]), &entries[i], true
+				break
+			}
+		}
+		if !found {
+			return models.ScenarioRecord{}, fmt.Errorf("run %q not found", id)
+		}
+	}
+	rec, err := w.readFile(fr, nil)
+	if err != nil {
+		return models.ScenarioRecord{}, err
+	}
+	if sd, err := traces.Load(id); err == nil {
+		rec.TracePoints = len(sd.MouseTrace)
+	}
+	return rec, nil
+}
+
+// parseRecord builds a ScenarioRecord from stats content, adding derived fields.
+// fullPath is used for naming only.
+func (w *Watcher) parseRecord(fullPath string, src io.Reader) (models.ScenarioRecord, error) {
 	info, err := parser.ParseFilename(filepath.Base(fullPath))
 	if err != nil {
 		return models.ScenarioRecord{}, err
 	}
-	events, stats, err := parser.ParseStatsFile(fullPath)
+	events, stats, err := parser.ParseStats(src)
 	if err != nil {
 		return models.ScenarioRecord{}, err
 	}
@@ -217,30 +511,21 @@ func (w *Watcher) parseFile(fullPath string) (models.ScenarioRecord, error) {
 		stats["Accuracy"] = 0.0
 	}
 
+	times := killTimes(events, info.DatePlayed)
 	// Real Avg TTK = average time between consecutive kill events (in seconds)
-	if len(events) >= 2 {
-		var times []time.Time
-		for _, row := range events {
-			if len(row) < 2 {
-				continue
-			}
-			if t, ok := parseTODOnDate(row[1], info.DatePlayed); ok {
-				times = append(times, t)
-			}
-		}
-		if len(times) >= 2 {
-			var sum time.Duration
-			for i := 1; i < len(times); i++ {
-				dt := times[i].Sub(times[i-1])
-				if dt > 0 {
-					sum += dt
-				}
-			}
-			intervals := len(times) - 1
-			if intervals > 0 {
-				stats["Real Avg TTK"] = sum.Seconds() / float64(intervals)
+	if len(times) >= 2 {
+		var sum time.Duration
+		for i := 1; i < len(times); i++ {
+			dt := times[i].Sub(times[i-1])
+			if dt > 0 {
+				sum += dt
 			}
 		}
+		stats["Real Avg TTK"] = sum.Seconds() / float64(len(times)-1)
+	}
+	// Within-run consistency from the kill timeline.
+	for k, v := range killstats.Derive(events, times) {
+		stats[k] = v
 	}
 
 	// Sensitivity normalized to cm/360 for filtering and charts. Always set; 0 means unsupported.
@@ -255,12 +540,25 @@ func (w *Watcher) parseFile(fullPath string) (models.ScenarioRecord, error) {
 		Events:   events,
 	}
 
-	// Optionally enrich with mouse trace based on Challenge Start -> DatePlayed interval
+	w.mu.RLock()
+	rec.Hidden = w.isHiddenLocked(info, stats, events)
+	w.mu.RUnlock()
+	return rec, nil
+}
+
+// attachTrace records the live mouse trace for a record's Challenge Start ->
+// DatePlayed interval, persisting it, or counts a previously persisted trace. Once
+// persisted, the trace is dropped from the record and fetched with Trace.
+func (w *Watcher) attachTrace(rec *models.ScenarioRecord) {
+	info, err := parser.ParseFilename(rec.FileName)
+	if err != nil {
+		return
+	}
 	w.mu.RLock()
 	mp := w.mouse
 	w.mu.RUnlock()
 	if mp != nil && mp.Enabled() {
-		start, end := deriveScenarioWindow(info.DatePlayed, stats, events)
+		start, end := deriveScenarioWindow(info.DatePlayed, rec.Stats, rec.Events)
 		if !start.IsZero() && !end.IsZero() && start.Before(end) {
 			rec.MouseTrace = mp.GetRange(start, end)
 			// debug
@@ -270,25 +568,76 @@ func (w *Watcher) parseFile(fullPath string) (models.ScenarioRecord, error) {
 
 	// If we captured a trace, persist it to disk for future reloads.
 	if len(rec.MouseTrace) > 0 {
+		rec.TracePoints = len(rec.MouseTrace)
 		// Only write if not already present to avoid churn.
-		if !traces.Exists(rec.FileName) {
-			_ = traces.Save(traces.ScenarioData{
+		persisted := traces.Exists(rec.FileName)
+		if !persisted {
+			persisted = traces.Save(traces.ScenarioData{
 				Version:      1,
 				FileName:     rec.FileName,
 				ScenarioName: info.ScenarioName,
 				DatePlayed:   info.DatePlayed.Format(time.RFC3339),
 				MouseTrace:   rec.MouseTrace,
-			})
+			}) == nil
+		}
+		// A trace that could not be saved stays in memory so it can still be viewed.
+		if persisted {
+			rec.MouseTrace = nil
 		}
 	} else {
-		// No live capture available (e.g., after restart). Attempt to load persisted data.
+		// No live capture available (e.g., after restart). Count persisted data.
 		if traces.Exists(rec.FileName) {
-			if sd, err := traces.Load(rec.FileName); err == nil && len(sd.MouseTrace) > 0 {
-				rec.MouseTrace = sd.MouseTrace
+			if sd, err := traces.Load(rec.FileName); err == nil {
+				rec.TracePoints = len(sd.MouseTrace)
 			}
 		}
 	}
-	return rec, nil
+}
+
+// Trace returns the samples of a run's mouse trace between from and to seconds
+// after its first sample (to <= 0 for the end), downsampled to at most maxPoints
+// (<= 0 for all) while keeping the path's shape.
+func (w *Watcher) Trace(id string, maxPoints int, from, to float64) (models.TraceSlice, error) {
+	trace, err := w.loadTrace(id)
+	if err != nil {
+		return models.TraceSlice{}, err
+	}
+	window := traces.Window(trace, from, to)
+	out := models.TraceSlice{
+		RunID:       id,
+		Total:       len(trace),
+		Matched:     len(window),
+		DurationSec: trace[len(trace)-1].TS.Sub(trace[0].TS).Seconds(),
+		FromSec:     math.Max(0, from),
+		Points:      append([]models.MousePoint{}, traces.Downsample(window, maxPoints)...),
+	}
+	out.ToSec = out.DurationSec
+	if to > 0 {
+		out.ToSec = math.Min(to, out.DurationSec)
+	}
+	return out, nil
+}
+
+// loadTrace returns a run's full mouse trace: from memory for a recent run whose
+// trace could not be persisted, otherwise from the traces directory.
+func (w *Watcher) loadTrace(id string) ([]models.MousePoint, error) {
+	w.mu.RLock()
+	for i := len(w.recent) - 1; i >= 0; i-- {
+		if w.recent[i].FileName == id && len(w.recent[i].MouseTrace) > 0 {
+			trace := w.recent[i].MouseTrace
+			w.mu.RUnlock()
+			return trace, nil
+		}
+	}
+	w.mu.RUnlock()
+	sd, err := traces.Load(id)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if len(sd.MouseTrace) == 0 {
+		return nil, errNoTrace
+	}
+	return sd.MouseTrace, nil
 }
 
 // deriveScenarioWindow attempts to compute the [start, end] timespan of a scenario.
@@ -323,6 +672,21 @@ func deriveScenarioWindow(end time.Time, stats map[string]any, events [][]string
 	return start, end
 }
 
+// killTimes returns the kill event timestamps placed on the played date, since the
+// stats file only records the time of day.
+func killTimes(events [][]string, date time.Time) []time.Time {
+	var times []time.Time
+	for _, row := range events {
+		if len(row) < 2 {
+			continue
+		}
+		if t, ok := parseTODOnDate(row[1], date); ok {
+			times = append(times, t)
+		}
+	}
+	return times
+}
+
 // parseTODOnDate parses a clock time string onto the provided date.
 func parseTODOnDate(s string, date time.Time) (time.Time, bool) {
 	// Support common formats with/without fractional seconds
@@ -367,20 +731,36 @@ func (w *Watcher) IsRunning() bool {
 	return w.running
 }
 
+// Config returns the current watcher configuration.
+func (w *Watcher) Config() models.WatcherConfig {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.cfg
+}
+
 // UpdateConfig safely updates the watcher configuration while stopped.
 func (w *Watcher) UpdateConfig(cfg models.WatcherConfig) error {
 	w.mu.Lock()
-	defer w.mu.Unlock()
 	if w.running {
+		w.mu.Unlock()
 		return errors.New("cannot update config while running")
 	}
+	regrouped := sessionGap(cfg) != sessionGap(w.cfg)
+	if regrouped {
+		w.sessions = sessions.NewTracker(sessionGap(cfg))
+		resetSessions(w.sessions, w.history.Visible())
+	}
 	w.cfg = cfg
+	w.mu.Unlock()
+	if regrouped {
+		runtime.EventsEmit(w.ctx, sessions.EventReset)
+	}
 	return nil
 }
 
-// ReloadTraces attempts to reload persisted mouse traces for recent scenarios
-// from the traces storage directory. For any records that gain a non-empty
-// MouseTrace as a result, a 'ScenarioUpdated' event is emitted.
+// ReloadTraces recounts persisted mouse traces for recent scenarios from the traces
+// storage directory. For any records whose trace changed as a result, a
+// 'ScenarioUpdated' event is emitted.
 func (w *Watcher) ReloadTraces() int {
 	// Copy updated records to emit outside the lock
 	var toEmit []models.ScenarioRecord
@@ -390,12 +770,11 @@ func (w *Watcher) ReloadTraces() int {
 		// Attempt to load persisted trace
 		if traces.Exists(rec.FileName) {
 			if sd, err := traces.Load(rec.FileName); err == nil {
-				if len(sd.MouseTrace) > 0 {
-					if !equalMouseTrace(rec.MouseTrace, sd.MouseTrace) {
-						rec.MouseTrace = sd.MouseTrace
-						w.recent[i] = rec
-						toEmit = append(toEmit, rec)
-					}
+				if len(sd.MouseTrace) > 0 && len(sd.MouseTrace) != rec.TracePoints {
+					rec.TracePoints = len(sd.MouseTrace)
+					rec.MouseTrace = nil
+					w.recent[i] = rec
+					toEmit = append(toEmit, rec)
 				}
 			}
 		}
@@ -414,19 +793,6 @@ func isKovaaksStatsFile(name string) bool {
 	return strings.HasSuffix(lower, " stats.csv")
 }
 
-// equalMouseTrace provides a fast equality check for two mouse traces.
-func equalMouseTrace(a, b []models.MousePoint) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	for i := range a {
-		if !a[i].TS.Equal(b[i].TS) || a[i].X != b[i].X || a[i].Y != b[i].Y {
-			return false
-		}
-	}
-	return true
-}
-
 // effectiveRecentCap returns the in-memory cap for recent scenarios.
 // If ParseExistingLimit is zero (parse all), we still bound memory to a sensible default.
 func (w *Watcher) effectiveRecentCap() int {
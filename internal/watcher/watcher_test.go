@@ -0,0 +1,124 @@
+package watcher
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"refleks/internal/history"
+	"refleks/internal/models"
+	"refleks/internal/parser"
+	"refleks/internal/sessions"
+	"refleks/internal/traces"
+)
+
+const testdataStats = "../../testdata/stats"
+
+// ingestWithTrace reads a testdata run into a watcher the way a scan does, with its
+// trace persisted to a temporary traces directory.
+func ingestWithTrace(t *testing.T, id string) (*Watcher, models.ScenarioRecord) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	traces.SetBaseDir("../../testdata/traces")
+	sd, err := traces.Load(id)
+	if err != nil {
+		t.Fatalf("load testdata trace: %v", err)
+	}
+	traces.SetBaseDir(filepath.Join(home, "traces"))
+	t.Cleanup(func() { traces.SetBaseDir("") })
+	if err := traces.Save(sd); err != nil {
+		t.Fatalf("save trace: %v", err)
+	}
+
+	w := New(context.Background(), models.WatcherConfig{Path: testdataStats})
+	info, err := parser.ParseFilename(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := w.readFile(fileRec{path: filepath.Join(testdataStats, id), name: id, t: info.DatePlayed}, nil)
+	if err != nil {
+		t.Fatalf("read stats: %v", err)
+	}
+	w.attachTrace(&rec)
+	w.recent = append(w.recent, rec)
+	w.history.Add(historyRun(rec))
+	return w, rec
+}
+
+func TestRecentRunTrace(t *testing.T) {
+	id := "VT 1w3ts Intermediate S5 - Challenge - 2025.10.26-13.12.08 Stats.csv"
+	w, rec := ingestWithTrace(t, id)
+	if rec.TracePoints == 0 || rec.MouseTrace != nil {
+		t.Fatalf("recent record should count its persisted trace without holding it: %d points, %d held", rec.TracePoints, len(rec.MouseTrace))
+	}
+
+	fa, err := w.FlickAnalysis(id, "deg")
+	if err != nil {
+		t.Fatalf("FlickAnalysis: %v", err)
+	}
+	if len(fa.Flicks) == 0 || fa.Unit != "deg" {
+		t.Errorf("got %d flicks in %q", len(fa.Flicks), fa.Unit)
+	}
+
+	pts, err := w.ConvertedTrace(id, "cm")
+	if err != nil {
+		t.Fatalf("ConvertedTrace: %v", err)
+	}
+	if len(pts) != rec.TracePoints {
+		t.Errorf("converted %d points, want %d", len(pts), rec.TracePoints)
+	}
+
+	cmp, err := w.CompareRuns(id, id)
+	if err != nil {
+		t.Fatalf("CompareRuns: %v", err)
+	}
+	if cmp.Trace == nil {
+		t.Error("comparison of a run with a trace should include the trace")
+	}
+
+	slice, err := w.Trace(id, 500, 0, 0)
+	if err != nil || slice.Total != rec.TracePoints || len(slice.Points) > 500 {
+		t.Errorf("Trace = %d of %d points, err %v", len(slice.Points), slice.Total, err)
+	}
+}
+
+func TestGroupBySession(t *testing.T) {
+	start := time.Date(2025, 10, 1, 18, 0, 0, 0, time.UTC)
+	at := func(id, scenario string, min int) history.Run {
+		s := start.Add(time.Duration(min) * time.Minute)
+		return history.Run{ID: id, Scenario: scenario, Start: s, End: s.Add(time.Minute)}
+	}
+	// A is played 30 minutes apart, bridged by B; the next day starts a new session.
+	all := []history.Run{at("1", "A", 0), at("2", "B", 10), at("3", "B", 20), at("4", "A", 30), at("5", "A", 24*60)}
+	tr := sessions.NewTracker(15 * time.Minute)
+	resetSessions(tr, all)
+	got := groupBySession([]history.Run{all[0], all[3], all[4]}, tr.Starts())
+	if len(got) != 2 || len(got[0]) != 2 || len(got[1]) != 1 {
+		t.Fatalf("expected A's runs in 2 sessions of 2 and 1, got %v", got)
+	}
+}
+
+func TestUnsavedTraceKinematics(t *testing.T) {
+	id := "VT 1w3ts Intermediate S5 - Challenge - 2025.10.26-13.12.08 Stats.csv"
+	traces.SetBaseDir("../../testdata/traces")
+	sd, err := traces.Load(id)
+	if err != nil {
+		t.Fatalf("load testdata trace: %v", err)
+	}
+	// An empty traces directory stands in for a save that failed.
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	traces.SetBaseDir(filepath.Join(home, "traces"))
+	t.Cleanup(func() { traces.SetBaseDir("") })
+
+	w := New(context.Background(), models.WatcherConfig{Path: testdataStats})
+	w.recent = append(w.recent, models.ScenarioRecord{FileName: id, MouseTrace: sd.MouseTrace})
+	for _, unit := range []string{"counts", ""} {
+		k, err := w.TraceKinematics(id, unit)
+		if err != nil || len(k.Profile) == 0 {
+			t.Errorf("TraceKinematics(%q) = %d profile bins, err %v", unit, len(k.Profile), err)
+		}
+	}
+}